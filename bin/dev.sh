@@ -4,4 +4,6 @@ if [[ "$@" == "single" ]]; then
   go run cmd/internal/single/single_model.go
 elif [[ "$@" == "multiple" ]]; then
   go run cmd/internal/multiple/multiple_model.go
+elif [[ "$1" == "prune" ]]; then
+  go run cmd/internal/prune/prune.go "${@:2}"
 fi
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/graphql-go/compatibility-base/config"
+	"github.com/graphql-go/compatibility-base/puller"
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func main() {
+	handleErr := func(err error) {
+		log.Fatal(err)
+	}
+
+	root := flag.String("root", "", "workspace root directory, defaults to the current working directory")
+	cacheDir := flag.String("cache", "", "cache directory whose mirrors are garbage collected")
+	lockfilePath := flag.String("lockfile", "", "lockfile path, defaults to the workspace repos.lock file")
+	dryRun := flag.Bool("dry-run", true, "only report what would be removed")
+	flag.Parse()
+
+	cfg := config.New()
+
+	ws, err := puller.NewWorkspace(*root)
+	if err != nil {
+		handleErr(err)
+	}
+
+	params := &puller.PruneParams{
+		Repositories: []types.Repository{
+			cfg.GraphqlSpecification.Repo,
+			cfg.GraphqlJSImplementation.Repo,
+			cfg.GraphqlGoImplementation.Repo,
+		},
+		Workspace:    ws,
+		LockfilePath: *lockfilePath,
+		DryRun:       *dryRun,
+	}
+
+	if *cacheDir != "" {
+		cache, err := puller.NewCache(*cacheDir)
+		if err != nil {
+			handleErr(err)
+		}
+
+		params.Cache = cache
+	}
+
+	result, err := puller.New().Prune(context.Background(), params)
+	if err != nil {
+		handleErr(err)
+	}
+
+	for _, entry := range result.Kept {
+		fmt.Printf("keep    %s (%d bytes)\n", entry.Path, entry.Size)
+	}
+
+	for _, removed := range result.Removed {
+		fmt.Printf("remove  %s (%d bytes, %s)\n", removed.Path, removed.Size, removed.Reason)
+	}
+
+	for _, mirror := range result.Mirrors {
+		fmt.Printf("gc      %s (%d -> %d bytes)\n", mirror.Path, mirror.SizeBefore, mirror.SizeAfter)
+	}
+
+	fmt.Println(result.Summary())
+}
@@ -0,0 +1,191 @@
+// package extractor extracts the test names of the cloned graphql implementations.
+package extractor
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/graphql-go/compatibility-base/puller"
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// testsDirName is the name of the directories of the graphql-js tests.
+const testsDirName = "__tests__"
+
+// skippedDirNames are the directory names that are never walked.
+var skippedDirNames = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+}
+
+// Extractor represents the component that extracts test names from code repositories.
+type Extractor struct {
+}
+
+// New returns a pointer to a Extractor struct.
+func New() *Extractor {
+	return &Extractor{}
+}
+
+// ExtractParams represents the parameters of the extract method.
+type ExtractParams struct {
+	// Implementation is the implementation whose test names are extracted.
+	// Its test names are updated and written to its test names file path when it is set.
+	Implementation *types.Implementation
+
+	// Dir is the directory of the cloned code repository, defaults to the implementation repository directory
+	// of the workspace.
+	Dir string
+
+	// Workspace is the workspace where the code repository was pulled, defaults to the current working directory.
+	Workspace *puller.Workspace
+}
+
+// Extract extracts the test names of the implementation code repository based on its type,
+// then writes them to the test names file path of the implementation.
+func (e *Extractor) Extract(params *ExtractParams) ([]string, error) {
+	impl := params.Implementation
+	if impl == nil {
+		return nil, fmt.Errorf("failed to extract test names: nil implementation")
+	}
+
+	dir := params.Dir
+	if dir == "" {
+		ws := params.Workspace
+		if ws == nil {
+			var err error
+
+			ws, err = puller.NewWorkspace("")
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		dir = ws.RepoDir(&impl.Repo)
+	}
+
+	var names []string
+	var err error
+
+	switch impl.Type {
+	case types.RefImplementationType:
+		names, err = e.ExtractJS(dir)
+	case types.GoImplementationType:
+		names, err = e.ExtractGo(dir)
+	default:
+		return nil, fmt.Errorf("failed to extract test names: unexpected implementation type: %d", impl.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	impl.TestNames = names
+
+	if impl.TestNamesFilePath != "" {
+		if err := WriteTestNames(impl.TestNamesFilePath, names); err != nil {
+			return nil, err
+		}
+	}
+
+	return names, nil
+}
+
+// ExtractJS extracts the fully qualified test names of the graphql-js tests of the given directory,
+// which are the `*.js` and `*.ts` files of the `__tests__` directories.
+func (e *Extractor) ExtractJS(dir string) ([]string, error) {
+	paths, err := walk(dir, func(path string) bool {
+		ext := filepath.Ext(path)
+
+		return filepath.Base(filepath.Dir(path)) == testsDirName && (ext == ".js" || ext == ".ts")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read a test file: %w", err)
+		}
+
+		fileNames, err := jsTestNames(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract the test names of %q: %w", path, err)
+		}
+
+		names = append(names, fileNames...)
+	}
+
+	return unique(names), nil
+}
+
+// WriteTestNames writes the given test names to the given file path, one test name per line.
+func WriteTestNames(path string, names []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create a directory: %w", err)
+	}
+
+	content := ""
+	if len(names) > 0 {
+		content = strings.Join(names, "\n") + "\n"
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write the test names: %w", err)
+	}
+
+	return nil
+}
+
+// walk returns the paths of the files of the given directory that match the given function, in lexical order.
+func walk(dir string, match func(path string) bool) ([]string, error) {
+	paths := []string{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && skippedDirNames[d.Name()] {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if d.Type().IsRegular() && match(path) {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk the directory: %w", err)
+	}
+
+	return paths, nil
+}
+
+// unique returns the given names without the duplicates, keeping the first occurrences order.
+func unique(names []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		result = append(result, name)
+	}
+
+	return result
+}
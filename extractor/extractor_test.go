@@ -0,0 +1,124 @@
+package extractor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/puller"
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestNew(t *testing.T) {
+	e := New()
+
+	if e == nil {
+		t.Fatalf("expected: %+v, got: nil", &Extractor{})
+	}
+}
+
+func TestExtractorExtract(t *testing.T) {
+	dir := t.TempDir()
+
+	testFiles(t, dir, map[string]string{
+		"src/language/__tests__/lexer-test.ts":   "describe('Lexer', () => { it('lexes strings', () => {}); });",
+		"src/language/__tests__/parser-test.js":  "describe('Parser', () => { it('parses', () => {}); });",
+		"src/language/__tests__/fixtures.json":   "{}",
+		"src/language/lexer.js":                  "describe('Not a test file', () => { it('x', () => {}); });",
+		"node_modules/mocha/__tests__/a-test.js": "describe('Dependency', () => { it('x', () => {}); });",
+	})
+
+	impl := &types.Implementation{
+		Repo:              types.Repository{Dir: dir},
+		Type:              types.RefImplementationType,
+		TestNamesFilePath: filepath.Join(t.TempDir(), "puller-js", "unit-tests.txt"),
+	}
+
+	names, err := New().Extract(&ExtractParams{Implementation: impl})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	expected := []string{"Lexer lexes strings", "Parser parses"}
+
+	assert.Equal(t, expected, names)
+	assert.Equal(t, expected, impl.TestNames)
+
+	content, err := os.ReadFile(impl.TestNamesFilePath)
+	if err != nil {
+		t.Fatalf("failed to read test names: %v", err)
+	}
+
+	assert.Equal(t, "Lexer lexes strings\nParser parses\n", string(content))
+}
+
+func TestExtractorExtractWorkspace(t *testing.T) {
+	ws, err := puller.NewWorkspace(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	impl := &types.Implementation{
+		Repo: types.Repository{Name: "graphql-js"},
+		Type: types.RefImplementationType,
+	}
+
+	testFiles(t, ws.RepoDir(&impl.Repo), map[string]string{
+		"src/language/__tests__/lexer-test.ts": "describe('Lexer', () => { it('lexes strings', () => {}); });",
+	})
+
+	names, err := New().Extract(&ExtractParams{Implementation: impl, Workspace: ws})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, []string{"Lexer lexes strings"}, names)
+}
+
+func TestExtractorExtractErrors(t *testing.T) {
+	tests := []struct {
+		subTestName    string
+		implementation *types.Implementation
+	}{
+		{
+			subTestName: "Handles nil implementation",
+		},
+		{
+			subTestName:    "Handles unexpected implementation type",
+			implementation: &types.Implementation{Repo: types.Repository{Dir: t.TempDir()}},
+		},
+		{
+			subTestName: "Handles missing directory",
+			implementation: &types.Implementation{
+				Repo: types.Repository{Dir: filepath.Join(t.TempDir(), "missing")},
+				Type: types.RefImplementationType,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			_, err := New().Extract(&ExtractParams{Implementation: tt.implementation})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+// testFiles writes the given files content, keyed by their slash separated path, into the given directory.
+func testFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+}
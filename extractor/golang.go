@@ -0,0 +1,474 @@
+package extractor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// goTestFileSuffix is the suffix of the go test files.
+const goTestFileSuffix = "_test.go"
+
+// goTestNameSeparator is the separator of the test and subtest names, the same as `go test -run`.
+const goTestNameSeparator = "/"
+
+// goSkippedDirNames are the directory names of the go code repositories that are never walked.
+var goSkippedDirNames = map[string]bool{
+	"vendor":   true,
+	"testdata": true,
+}
+
+// ExtractGo extracts the test names of the go tests of the given directory, which are the `TestXxx` functions
+// of the `_test.go` files and their statically visible `t.Run` subtests, eg. `TestParse/parses_a_query`.
+func (e *Extractor) ExtractGo(dir string) ([]string, error) {
+	paths, err := walk(dir, func(path string) bool {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return false
+		}
+
+		for _, segment := range strings.Split(filepath.ToSlash(rel), "/") {
+			if goSkippedDirNames[segment] {
+				return false
+			}
+		}
+
+		return strings.HasSuffix(path, goTestFileSuffix)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for _, path := range paths {
+		fileNames, err := goTestNames(path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract the test names of %q: %w", path, err)
+		}
+
+		names = append(names, fileNames...)
+	}
+
+	return unique(names), nil
+}
+
+// goTestNames returns the test names of the given go test file, in source order.
+// The source is read from the file path when it is nil.
+func goTestNames(path string, src any) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse a go test file: %w", err)
+	}
+
+	names := []string{}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || !isGoTestName(fn.Name.Name) {
+			continue
+		}
+
+		tName := goTestParamName(fn.Type)
+		if tName == "" {
+			continue
+		}
+
+		names = append(names, fn.Name.Name)
+
+		v := &goTestVisitor{file: file, fn: fn}
+		v.visit(fn.Body, tName, fn.Name.Name, nil)
+
+		names = append(names, v.names...)
+	}
+
+	return names, nil
+}
+
+// goTestVisitor represents the visitor of a go test function that collects its subtest names.
+type goTestVisitor struct {
+	// file is the go test file.
+	file *ast.File
+
+	// fn is the visited test function.
+	fn *ast.FuncDecl
+
+	// names are the collected subtest names.
+	names []string
+}
+
+// visit collects the subtest names of the `t.Run` calls of the given node, where `t` is the given variable name
+// and the subtest names are prefixed by the given parent test name.
+// The given stack contains the enclosing nodes, used to resolve the table-driven subtest names.
+func (v *goTestVisitor) visit(node ast.Node, tName string, parent string, stack []ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isGoRunCall(call, tName) {
+			stack = append(stack, n)
+			return true
+		}
+
+		for _, name := range v.subTestNames(call.Args[0], stack) {
+			fullName := parent + goTestNameSeparator + rewriteGoSubTestName(name)
+			v.names = append(v.names, fullName)
+
+			lit, ok := call.Args[1].(*ast.FuncLit)
+			if !ok {
+				continue
+			}
+
+			if subTName := goTestParamName(lit.Type); subTName != "" {
+				v.visit(lit.Body, subTName, fullName, append(stack[:len(stack):len(stack)], call))
+			}
+		}
+
+		return false
+	})
+}
+
+// subTestNames returns the literal subtest names of the given `t.Run` name argument,
+// either a string literal or a field or a key of a table-driven test, eg. `tt.name`.
+func (v *goTestVisitor) subTestNames(arg ast.Expr, stack []ast.Node) []string {
+	switch arg := arg.(type) {
+	case *ast.BasicLit:
+		if name, ok := goStringLiteral(arg); ok {
+			return []string{name}
+		}
+	case *ast.Ident:
+		if rangeStmt := goRangeStmt(stack, arg.Name, true); rangeStmt != nil {
+			return goMapKeys(v.table(rangeStmt.X))
+		}
+	case *ast.SelectorExpr:
+		ident, ok := arg.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+
+		if rangeStmt := goRangeStmt(stack, ident.Name, false); rangeStmt != nil {
+			return v.tableFields(v.table(rangeStmt.X), arg.Sel.Name)
+		}
+	}
+
+	return nil
+}
+
+// table returns the composite literal of the given ranged expression, either the literal itself
+// or the literal assigned to the ranged variable in the test function or in the file.
+func (v *goTestVisitor) table(expr ast.Expr) *ast.CompositeLit {
+	switch expr := expr.(type) {
+	case *ast.CompositeLit:
+		return expr
+	case *ast.Ident:
+		var table *ast.CompositeLit
+
+		ast.Inspect(v.fn.Body, func(n ast.Node) bool {
+			if assign, ok := n.(*ast.AssignStmt); ok && table == nil {
+				table = goAssignedLiteral(assign.Lhs, assign.Rhs, expr.Name)
+			}
+
+			if spec, ok := n.(*ast.ValueSpec); ok && table == nil {
+				table = goValueSpecLiteral(spec, expr.Name)
+			}
+
+			return table == nil
+		})
+
+		if table != nil {
+			return table
+		}
+
+		for _, decl := range v.file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				if table := goValueSpecLiteral(spec.(*ast.ValueSpec), expr.Name); table != nil {
+					return table
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// tableFields returns the literal values of the given field of the elements of the given table.
+// Keyed elements are supported, as well as positional elements of a struct type declared in the test file.
+func (v *goTestVisitor) tableFields(table *ast.CompositeLit, field string) []string {
+	if table == nil {
+		return nil
+	}
+
+	fieldIdx := -1
+	if structType := v.elementStructType(table.Type); structType != nil {
+		fieldIdx = goStructFieldIndex(structType, field)
+	}
+
+	names := []string{}
+
+	for _, elt := range table.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value
+		}
+
+		if unary, ok := elt.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			elt = unary.X
+		}
+
+		lit, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+
+		for i, e := range lit.Elts {
+			var value ast.Expr
+
+			if kv, ok := e.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok && key.Name == field {
+					value = kv.Value
+				}
+			} else if i == fieldIdx {
+				value = e
+			}
+
+			if basic, ok := value.(*ast.BasicLit); ok {
+				if name, ok := goStringLiteral(basic); ok {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// elementStructType returns the struct type of the elements of the given table type, nil when it is unknown.
+func (v *goTestVisitor) elementStructType(tableType ast.Expr) *ast.StructType {
+	var elt ast.Expr
+
+	switch tableType := tableType.(type) {
+	case *ast.ArrayType:
+		elt = tableType.Elt
+	case *ast.MapType:
+		elt = tableType.Value
+	default:
+		return nil
+	}
+
+	if star, ok := elt.(*ast.StarExpr); ok {
+		elt = star.X
+	}
+
+	switch elt := elt.(type) {
+	case *ast.StructType:
+		return elt
+	case *ast.Ident:
+		for _, decl := range v.file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok && typeSpec.Name.Name == elt.Name {
+					return structType
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// goMapKeys returns the string literal keys of the given map literal.
+func goMapKeys(table *ast.CompositeLit) []string {
+	if table == nil {
+		return nil
+	}
+
+	names := []string{}
+
+	for _, elt := range table.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		if basic, ok := kv.Key.(*ast.BasicLit); ok {
+			if name, ok := goStringLiteral(basic); ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// goRangeStmt returns the innermost range statement of the given stack that declares the given variable,
+// as its key when isKey is true, or as its value otherwise.
+func goRangeStmt(stack []ast.Node, name string, isKey bool) *ast.RangeStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		rangeStmt, ok := stack[i].(*ast.RangeStmt)
+		if !ok {
+			continue
+		}
+
+		variable := rangeStmt.Value
+		if isKey {
+			variable = rangeStmt.Key
+		}
+
+		if ident, ok := variable.(*ast.Ident); ok && ident.Name == name {
+			return rangeStmt
+		}
+	}
+
+	return nil
+}
+
+// goAssignedLiteral returns the composite literal assigned to the given variable name, nil when there is none.
+func goAssignedLiteral(lhs []ast.Expr, rhs []ast.Expr, name string) *ast.CompositeLit {
+	if len(lhs) != len(rhs) {
+		return nil
+	}
+
+	for i, l := range lhs {
+		if ident, ok := l.(*ast.Ident); ok && ident.Name == name {
+			lit, _ := rhs[i].(*ast.CompositeLit)
+			return lit
+		}
+	}
+
+	return nil
+}
+
+// goValueSpecLiteral returns the composite literal of the given variable name of the given value spec,
+// nil when there is none.
+func goValueSpecLiteral(spec *ast.ValueSpec, name string) *ast.CompositeLit {
+	lhs := []ast.Expr{}
+	for _, n := range spec.Names {
+		lhs = append(lhs, n)
+	}
+
+	return goAssignedLiteral(lhs, spec.Values, name)
+}
+
+// goStructFieldIndex returns the index of the given field of the given struct type, -1 when it is not found.
+func goStructFieldIndex(structType *ast.StructType, field string) int {
+	idx := 0
+
+	for _, f := range structType.Fields.List {
+		if len(f.Names) == 0 {
+			idx++
+			continue
+		}
+
+		for _, n := range f.Names {
+			if n.Name == field {
+				return idx
+			}
+
+			idx++
+		}
+	}
+
+	return -1
+}
+
+// goTestParamName returns the name of the `*testing.T` parameter of the given function type,
+// empty when the function is not a test function.
+func goTestParamName(fnType *ast.FuncType) string {
+	if fnType.Params == nil || len(fnType.Params.List) != 1 || len(fnType.Params.List[0].Names) != 1 {
+		return ""
+	}
+
+	param := fnType.Params.List[0]
+
+	star, ok := param.Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "T" {
+		return ""
+	}
+
+	return param.Names[0].Name
+}
+
+// isGoRunCall returns whether the given call is a `t.Run` call, where `t` is the given variable name.
+func isGoRunCall(call *ast.CallExpr, tName string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Run" || len(call.Args) != 2 {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+
+	return ok && ident.Name == tName
+}
+
+// isGoTestName returns whether the given function name is a test function name, eg. `TestXxx`,
+// the same as `go test`.
+func isGoTestName(name string) bool {
+	if !strings.HasPrefix(name, "Test") {
+		return false
+	}
+
+	if len(name) == len("Test") {
+		return true
+	}
+
+	r, _ := utf8.DecodeRuneInString(name[len("Test"):])
+
+	return !unicode.IsLower(r)
+}
+
+// goStringLiteral returns the value of the given string literal.
+func goStringLiteral(lit *ast.BasicLit) (string, bool) {
+	if lit.Kind != token.STRING {
+		return "", false
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// rewriteGoSubTestName returns the given subtest name as reported by `go test`,
+// the spaces are replaced by underscores and the non printable characters are escaped.
+func rewriteGoSubTestName(name string) string {
+	var b strings.Builder
+
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			b.WriteRune('_')
+		case !strconv.IsPrint(r):
+			s := strconv.QuoteRune(r)
+			b.WriteString(s[1 : len(s)-1])
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,158 @@
+package extractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestGoTestNames(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		src         string
+		expected    []string
+	}{
+		{
+			subTestName: "Handles test functions",
+			src: `package graphql
+
+import "testing"
+
+func TestParse(t *testing.T) {}
+
+func Testparse(t *testing.T) {}
+
+func TestHelper(t *testing.B) {}
+
+func helper(t *testing.T) {}
+
+func (s *suite) TestMethod(t *testing.T) {}
+`,
+			expected: []string{"TestParse"},
+		},
+		{
+			subTestName: "Handles literal and nested subtests",
+			src: `package graphql
+
+import gotesting "testing"
+
+func TestExecute(test *gotesting.T) {
+	test.Run("handles nulls", func(t *gotesting.T) {
+		t.Run("in lists", func(t *gotesting.T) {})
+	})
+	test.Run(fmt.Sprintf("dynamic %d", 1), func(t *gotesting.T) {
+		t.Run("not visible", func(t *gotesting.T) {})
+	})
+}
+`,
+			expected: []string{
+				"TestExecute",
+				"TestExecute/handles_nulls",
+				"TestExecute/handles_nulls/in_lists",
+			},
+		},
+		{
+			subTestName: "Handles table-driven subtests",
+			src: `package graphql
+
+import "testing"
+
+type testCase struct {
+	name     string
+	expected int
+}
+
+var cases = []testCase{
+	{"package table", 1},
+	{name: "keyed package table"},
+}
+
+func TestTables(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		expected    int
+	}{
+		{subTestName: "Handles first", expected: 1},
+		{subTestName: fmt.Sprint("dynamic")},
+		{expected: 2, subTestName: "Handles second"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {})
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {})
+	}
+
+	for name := range map[string]int{"map key": 1} {
+		t.Run(name, func(t *testing.T) {})
+	}
+
+	for _, tt := range []*struct{ name string }{{"inline"}, {name: "inline keyed"}} {
+		t.Run(tt.name, func(t *testing.T) {})
+	}
+}
+`,
+			expected: []string{
+				"TestTables",
+				"TestTables/Handles_first",
+				"TestTables/Handles_second",
+				"TestTables/package_table",
+				"TestTables/keyed_package_table",
+				"TestTables/map_key",
+				"TestTables/inline",
+				"TestTables/inline_keyed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			names, err := goTestNames("graphql_test.go", tt.src)
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestGoTestNamesError(t *testing.T) {
+	_, err := goTestNames("graphql_test.go", "package graphql\n\nfunc TestParse(t *testing.T) {\n")
+	assert.NotNil(t, err)
+}
+
+func TestExtractorExtractGo(t *testing.T) {
+	dir := t.TempDir()
+
+	testFiles(t, dir, map[string]string{
+		"graphql_test.go":            "package graphql\n\nfunc TestGraphql(t *testing.T) {}\n",
+		"language/parser_test.go":    "package parser\n\nfunc TestParser(t *testing.T) {}\n",
+		"language/parser.go":         "package parser\n\nfunc TestNotATestFile(t *testing.T) {}\n",
+		"vendor/dep/dep_test.go":     "package dep\n\nfunc TestVendored(t *testing.T) {}\n",
+		"testdata/broken_test.go":    "package broken\n\nfunc TestBroken(",
+		"examples/example_test.go":   "package examples\n\nfunc TestGraphql(t *testing.T) {}\n",
+		"node_modules/x/a_test.go":   "package x\n\nfunc TestNodeModules(t *testing.T) {}\n",
+		".git/hooks/hook_test.go":    "package hooks\n\nfunc TestHook(t *testing.T) {}\n",
+		"language/lexer/lex_test.go": "package lexer\n\nfunc TestLex(t *testing.T) {}\n",
+	})
+
+	impl := &types.Implementation{
+		Repo: types.Repository{Dir: dir},
+		Type: types.GoImplementationType,
+	}
+
+	names, err := New().Extract(&ExtractParams{Implementation: impl})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	expected := []string{"TestGraphql", "TestLex", "TestParser"}
+
+	assert.Equal(t, expected, names)
+	assert.Equal(t, expected, impl.TestNames)
+}
@@ -0,0 +1,393 @@
+package extractor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// jsTestNameSeparator is the separator of the suite and test titles of a fully qualified test name,
+// the same as the mocha full titles.
+const jsTestNameSeparator = " "
+
+// jsTokenKind is the kind of a javascript token.
+type jsTokenKind uint
+
+const (
+	// jsIdentToken is the kind of identifiers, keywords and numbers.
+	jsIdentToken jsTokenKind = iota + 1
+
+	// jsStringToken is the kind of string and template literals.
+	jsStringToken
+
+	// jsPunctToken is the kind of punctuators.
+	jsPunctToken
+)
+
+// jsToken represents a javascript token.
+type jsToken struct {
+	// kind is the token kind.
+	kind jsTokenKind
+
+	// value is the token value, the unquoted content of the string literals.
+	value string
+}
+
+// is returns whether the token has the given kind and value.
+func (t jsToken) is(kind jsTokenKind, value string) bool {
+	return t.kind == kind && t.value == value
+}
+
+// jsSuite represents an open `describe` block.
+type jsSuite struct {
+	// title is the suite title.
+	title string
+
+	// depth is the parentheses depth inside the `describe` call.
+	depth int
+
+	// skipped represents whether or not the suite is skipped, eg. `describe.skip`.
+	skipped bool
+}
+
+// jsTestNames returns the fully qualified test names of the given javascript or typescript source,
+// in source order. Only the `describe` and `it` calls with a literal title are extracted,
+// the skipped suites and tests are ignored.
+func jsTestNames(src string) ([]string, error) {
+	tokens, err := jsTokens(src)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	suites := []jsSuite{}
+	depth := 0
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+
+		switch {
+		case tok.is(jsPunctToken, "("):
+			depth++
+		case tok.is(jsPunctToken, ")"):
+			depth--
+
+			for len(suites) > 0 && suites[len(suites)-1].depth > depth {
+				suites = suites[:len(suites)-1]
+			}
+		case tok.kind == jsIdentToken && (tok.value == "describe" || tok.value == "it"):
+			if i > 0 && tokens[i-1].is(jsPunctToken, ".") {
+				continue
+			}
+
+			open, title, skipped, ok := jsTestCall(tokens, i)
+			if !ok {
+				continue
+			}
+
+			skipped = skipped || (len(suites) > 0 && suites[len(suites)-1].skipped)
+
+			i = open
+			depth++
+
+			if tok.value == "describe" {
+				suites = append(suites, jsSuite{title: title, depth: depth, skipped: skipped})
+				continue
+			}
+
+			if skipped {
+				continue
+			}
+
+			titles := []string{}
+			for _, s := range suites {
+				titles = append(titles, s.title)
+			}
+
+			names = append(names, strings.Join(append(titles, title), jsTestNameSeparator))
+		}
+	}
+
+	return names, nil
+}
+
+// jsTestCall parses the `describe` or `it` call starting at the given token index,
+// eg. `describe.only('title', ...)`.
+// Returns the index of the opening parenthesis, the title and whether or not the call is skipped.
+func jsTestCall(tokens []jsToken, idx int) (int, string, bool, bool) {
+	i := idx + 1
+	skipped := false
+
+	if i+1 < len(tokens) && tokens[i].is(jsPunctToken, ".") && tokens[i+1].kind == jsIdentToken {
+		switch tokens[i+1].value {
+		case "only":
+		case "skip":
+			skipped = true
+		default:
+			return 0, "", false, false
+		}
+
+		i += 2
+	}
+
+	if i+1 >= len(tokens) || !tokens[i].is(jsPunctToken, "(") || tokens[i+1].kind != jsStringToken {
+		return 0, "", false, false
+	}
+
+	title := tokens[i+1].value
+
+	for j := i + 2; j+1 < len(tokens) && tokens[j].is(jsPunctToken, "+") && tokens[j+1].kind == jsStringToken; j += 2 {
+		title += tokens[j+1].value
+	}
+
+	return i, title, skipped, true
+}
+
+// jsTokens returns the tokens of the given javascript or typescript source, the comments and the regular
+// expression literals are skipped.
+func jsTokens(src string) ([]jsToken, error) {
+	s := &jsScanner{src: src, line: 1}
+
+	for s.pos < len(s.src) {
+		if err := s.next(); err != nil {
+			return nil, fmt.Errorf("failed to scan line %d: %w", s.line, err)
+		}
+	}
+
+	return s.tokens, nil
+}
+
+// jsScanner represents the scanner of a javascript or typescript source.
+type jsScanner struct {
+	// src is the scanned source.
+	src string
+
+	// pos is the current byte offset.
+	pos int
+
+	// line is the current line number.
+	line int
+
+	// tokens are the scanned tokens.
+	tokens []jsToken
+}
+
+// next scans the next token of the source.
+func (s *jsScanner) next() error {
+	c := s.src[s.pos]
+
+	switch {
+	case c == '\n':
+		s.line++
+		s.pos++
+	case c == ' ' || c == '\t' || c == '\r':
+		s.pos++
+	case strings.HasPrefix(s.src[s.pos:], "//"):
+		s.skipLineComment()
+	case strings.HasPrefix(s.src[s.pos:], "/*"):
+		return s.skipBlockComment()
+	case c == '\'' || c == '"':
+		return s.scanString(c)
+	case c == '`':
+		return s.scanTemplate()
+	case c == '/' && s.regexpAllowed():
+		return s.skipRegexp()
+	case isJSIdentByte(c):
+		start := s.pos
+		for s.pos < len(s.src) && isJSIdentByte(s.src[s.pos]) {
+			s.pos++
+		}
+
+		s.tokens = append(s.tokens, jsToken{kind: jsIdentToken, value: s.src[start:s.pos]})
+	default:
+		s.tokens = append(s.tokens, jsToken{kind: jsPunctToken, value: string(c)})
+		s.pos++
+	}
+
+	return nil
+}
+
+// skipLineComment skips a `//` comment.
+func (s *jsScanner) skipLineComment() {
+	for s.pos < len(s.src) && s.src[s.pos] != '\n' {
+		s.pos++
+	}
+}
+
+// skipBlockComment skips a `/* */` comment.
+func (s *jsScanner) skipBlockComment() error {
+	end := strings.Index(s.src[s.pos+2:], "*/")
+	if end < 0 {
+		return fmt.Errorf("unterminated comment")
+	}
+
+	comment := s.src[s.pos : s.pos+2+end+2]
+	s.line += strings.Count(comment, "\n")
+	s.pos += len(comment)
+
+	return nil
+}
+
+// scanString scans a string literal quoted by the given quote.
+func (s *jsScanner) scanString(quote byte) error {
+	var b strings.Builder
+
+	for s.pos++; s.pos < len(s.src); s.pos++ {
+		c := s.src[s.pos]
+
+		switch c {
+		case quote:
+			s.pos++
+			s.tokens = append(s.tokens, jsToken{kind: jsStringToken, value: b.String()})
+
+			return nil
+		case '\n':
+			return fmt.Errorf("unterminated string")
+		case '\\':
+			s.pos++
+			if s.pos < len(s.src) {
+				b.WriteString(jsEscape(s.src[s.pos]))
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return fmt.Errorf("unterminated string")
+}
+
+// scanTemplate scans a template literal, the substitutions are kept as is in its value, eg. `${name}`.
+func (s *jsScanner) scanTemplate() error {
+	var b strings.Builder
+
+	for s.pos++; s.pos < len(s.src); s.pos++ {
+		c := s.src[s.pos]
+
+		switch {
+		case c == '`':
+			s.pos++
+			s.tokens = append(s.tokens, jsToken{kind: jsStringToken, value: b.String()})
+
+			return nil
+		case c == '\\':
+			s.pos++
+			if s.pos < len(s.src) {
+				b.WriteString(jsEscape(s.src[s.pos]))
+			}
+		case strings.HasPrefix(s.src[s.pos:], "${"):
+			end, err := s.substitutionEnd(s.pos + 2)
+			if err != nil {
+				return err
+			}
+
+			b.WriteString(s.src[s.pos : end+1])
+			s.pos = end
+		default:
+			if c == '\n' {
+				s.line++
+			}
+
+			b.WriteByte(c)
+		}
+	}
+
+	return fmt.Errorf("unterminated template literal")
+}
+
+// substitutionEnd returns the offset of the closing brace of the template substitution starting at the given
+// offset, the nested braces and strings are skipped.
+func (s *jsScanner) substitutionEnd(start int) (int, error) {
+	depth := 1
+
+	for i := start; i < len(s.src); i++ {
+		switch c := s.src[i]; c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		case '\'', '"', '`':
+			end := strings.IndexByte(s.src[i+1:], c)
+			if end < 0 {
+				return 0, fmt.Errorf("unterminated template literal")
+			}
+
+			i += end + 1
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated template literal")
+}
+
+// skipRegexp skips a regular expression literal, including its character classes and flags.
+func (s *jsScanner) skipRegexp() error {
+	inClass := false
+
+	for s.pos++; s.pos < len(s.src); s.pos++ {
+		switch s.src[s.pos] {
+		case '\\':
+			s.pos++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '\n':
+			return fmt.Errorf("unterminated regular expression")
+		case '/':
+			if inClass {
+				continue
+			}
+
+			s.pos++
+			for s.pos < len(s.src) && isJSIdentByte(s.src[s.pos]) {
+				s.pos++
+			}
+
+			s.tokens = append(s.tokens, jsToken{kind: jsIdentToken, value: "regexp"})
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unterminated regular expression")
+}
+
+// regexpAllowed returns whether a `/` starts a regular expression literal instead of a division,
+// based on the previous token.
+func (s *jsScanner) regexpAllowed() bool {
+	if len(s.tokens) == 0 {
+		return true
+	}
+
+	prev := s.tokens[len(s.tokens)-1]
+
+	switch prev.kind {
+	case jsIdentToken:
+		return prev.value == "return" || prev.value == "typeof" || prev.value == "case"
+	case jsStringToken:
+		return false
+	default:
+		return prev.value != ")" && prev.value != "]" && prev.value != "}"
+	}
+}
+
+// isJSIdentByte returns whether the given byte is part of an identifier, keyword or number.
+func isJSIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// jsEscape returns the value of the given escaped character.
+func jsEscape(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 't':
+		return "\t"
+	case '\n':
+		return ""
+	default:
+		return string(c)
+	}
+}
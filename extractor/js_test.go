@@ -0,0 +1,122 @@
+package extractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSTestNames(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		src         string
+		expected    []string
+	}{
+		{
+			subTestName: "Handles nested describe and it calls",
+			src: `
+import { expect } from 'chai';
+import { describe, it } from 'mocha';
+
+describe('Lexer', () => {
+  it('disallows uncommon control characters', () => {
+    expect(lexOne('\u0007')).to.throw();
+  });
+
+  describe('punctuation', () => {
+    it("lexes ... and !", () => {});
+  });
+
+  it('accepts BOM header', () => {});
+});
+`,
+			expected: []string{
+				"Lexer disallows uncommon control characters",
+				"Lexer punctuation lexes ... and !",
+				"Lexer accepts BOM header",
+			},
+		},
+		{
+			subTestName: "Handles comments, regular expressions and template literals",
+			src: `
+// describe('commented', () => { it('out', () => {}); });
+/*
+ * it('block commented', () => {});
+ */
+describe(` + "`Schema ${'Printer'}`" + `, () => {
+  const re = /it\('(not a test)'\)/g;
+  const x = a / b / c;
+  it('prints ' + "string field", () => {
+    expect(print(` + "`type Query { it: String }`" + `)).to.equal('}');
+  });
+});
+`,
+			expected: []string{
+				"Schema ${'Printer'} prints string field",
+			},
+		},
+		{
+			subTestName: "Handles only and skipped calls",
+			src: `
+describe.only('Execute', function () {
+  it.skip('skipped test', function () {});
+  it.only('focused test', async function () {});
+  describe.skip('skipped suite', () => {
+    it('nested test', () => {});
+  });
+});
+`,
+			expected: []string{
+				"Execute focused test",
+			},
+		},
+		{
+			subTestName: "Handles non test calls",
+			src: `
+function it(name) {}
+obj.it('member call', () => {});
+describe(title, () => {});
+it.each([1, 2])('each %d', () => {});
+`,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			names, err := jsTestNames(tt.src)
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestJSTestNamesErrors(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		src         string
+	}{
+		{
+			subTestName: "Handles unterminated string",
+			src:         "describe('Lexer, () => {});\n",
+		},
+		{
+			subTestName: "Handles unterminated comment",
+			src:         "/* describe('Lexer', () => {});\n",
+		},
+		{
+			subTestName: "Handles unterminated template literal",
+			src:         "describe(`Lexer, () => {});\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			_, err := jsTestNames(tt.src)
+			assert.NotNil(t, err)
+		})
+	}
+}
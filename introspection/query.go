@@ -0,0 +1,254 @@
+// package introspection generates the graphql introspection query.
+package introspection
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultTypeDepth is the default number of nested `ofType` selections of the type references.
+const DefaultTypeDepth = 9
+
+// Edition is a graphql specification edition, which defines the introspection fields an implementation supports.
+type Edition uint
+
+const (
+	// June2018Edition is the June 2018 graphql specification edition.
+	June2018Edition Edition = iota + 1
+
+	// October2021Edition is the October 2021 graphql specification edition.
+	October2021Edition
+
+	// DraftEdition is the working draft of the graphql specification.
+	DraftEdition
+)
+
+// String returns the string representation of the edition.
+func (e Edition) String() string {
+	switch e {
+	case June2018Edition:
+		return "June2018"
+	case October2021Edition:
+		return "October2021"
+	case DraftEdition:
+		return "draft"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseEdition returns the edition of the given name, eg. `October2021`.
+func ParseEdition(name string) (Edition, error) {
+	for _, e := range []Edition{June2018Edition, October2021Edition, DraftEdition} {
+		if strings.EqualFold(e.String(), name) {
+			return e, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unexpected specification edition: %q", name)
+}
+
+// Options represents the options of the introspection query.
+type Options struct {
+	// Descriptions includes the descriptions of the schema elements.
+	Descriptions bool
+
+	// SpecifiedByURL includes the `specifiedByURL` of the scalar types.
+	SpecifiedByURL bool
+
+	// DirectiveIsRepeatable includes the `isRepeatable` flag of the directives.
+	DirectiveIsRepeatable bool
+
+	// SchemaDescription includes the description of the schema.
+	SchemaDescription bool
+
+	// InputValueDeprecation includes the deprecated arguments and input fields, and their deprecation.
+	InputValueDeprecation bool
+
+	// OneOf includes the `isOneOf` flag of the input object types.
+	OneOf bool
+
+	// TypeDepth is the number of nested `ofType` selections of the type references, defaults to DefaultTypeDepth.
+	TypeDepth int
+}
+
+// EditionOptions returns the options of the introspection fields supported by the given edition.
+func EditionOptions(e Edition) Options {
+	switch e {
+	case June2018Edition:
+		return Options{
+			Descriptions: true,
+			TypeDepth:    DefaultTypeDepth,
+		}
+	case October2021Edition:
+		return Options{
+			Descriptions:          true,
+			SpecifiedByURL:        true,
+			DirectiveIsRepeatable: true,
+			SchemaDescription:     true,
+			TypeDepth:             DefaultTypeDepth,
+		}
+	default:
+		return Options{
+			Descriptions:          true,
+			SpecifiedByURL:        true,
+			DirectiveIsRepeatable: true,
+			SchemaDescription:     true,
+			InputValueDeprecation: true,
+			OneOf:                 true,
+			TypeDepth:             DefaultTypeDepth,
+		}
+	}
+}
+
+// EditionQuery returns the introspection query of the fields supported by the given edition.
+func EditionQuery(e Edition) string {
+	return Query(EditionOptions(e))
+}
+
+// Query returns the introspection query text of the given options.
+func Query(opts Options) string {
+	if opts.TypeDepth <= 0 {
+		opts.TypeDepth = DefaultTypeDepth
+	}
+
+	includeDeprecated := ""
+	if opts.InputValueDeprecation {
+		includeDeprecated = "(includeDeprecated: true)"
+	}
+
+	w := &queryWriter{}
+
+	w.open("query IntrospectionQuery")
+	w.open("__schema")
+	w.lineIf(opts.SchemaDescription, "description")
+	w.line("queryType { name kind }")
+	w.line("mutationType { name kind }")
+	w.line("subscriptionType { name kind }")
+	w.open("types")
+	w.line("...FullType")
+	w.close()
+	w.open("directives")
+	w.line("name")
+	w.lineIf(opts.Descriptions, "description")
+	w.lineIf(opts.DirectiveIsRepeatable, "isRepeatable")
+	w.line("locations")
+	w.open("args" + includeDeprecated)
+	w.line("...InputValue")
+	w.close()
+	w.close()
+	w.close()
+	w.close()
+
+	w.blank()
+	w.open("fragment FullType on __Type")
+	w.line("kind")
+	w.line("name")
+	w.lineIf(opts.Descriptions, "description")
+	w.lineIf(opts.SpecifiedByURL, "specifiedByURL")
+	w.lineIf(opts.OneOf, "isOneOf")
+	w.open("fields(includeDeprecated: true)")
+	w.line("name")
+	w.lineIf(opts.Descriptions, "description")
+	w.open("args" + includeDeprecated)
+	w.line("...InputValue")
+	w.close()
+	w.open("type")
+	w.line("...TypeRef")
+	w.close()
+	w.line("isDeprecated")
+	w.line("deprecationReason")
+	w.close()
+	w.open("inputFields" + includeDeprecated)
+	w.line("...InputValue")
+	w.close()
+	w.open("interfaces")
+	w.line("...TypeRef")
+	w.close()
+	w.open("enumValues(includeDeprecated: true)")
+	w.line("name")
+	w.lineIf(opts.Descriptions, "description")
+	w.line("isDeprecated")
+	w.line("deprecationReason")
+	w.close()
+	w.open("possibleTypes")
+	w.line("...TypeRef")
+	w.close()
+	w.close()
+
+	w.blank()
+	w.open("fragment InputValue on __InputValue")
+	w.line("name")
+	w.lineIf(opts.Descriptions, "description")
+	w.open("type")
+	w.line("...TypeRef")
+	w.close()
+	w.line("defaultValue")
+	w.lineIf(opts.InputValueDeprecation, "isDeprecated")
+	w.lineIf(opts.InputValueDeprecation, "deprecationReason")
+	w.close()
+
+	w.blank()
+	w.open("fragment TypeRef on __Type")
+	w.line("kind")
+	w.line("name")
+
+	for i := 0; i < opts.TypeDepth; i++ {
+		w.open("ofType")
+		w.line("kind")
+		w.line("name")
+	}
+
+	for i := 0; i < opts.TypeDepth; i++ {
+		w.close()
+	}
+
+	w.close()
+
+	return w.String()
+}
+
+// queryWriter represents the writer of the indented introspection query lines.
+type queryWriter struct {
+	// b is the buffer of the written lines.
+	b strings.Builder
+
+	// depth is the current indentation depth.
+	depth int
+}
+
+// line writes the given line at the current indentation depth.
+func (w *queryWriter) line(s string) {
+	w.b.WriteString(strings.Repeat("  ", w.depth))
+	w.b.WriteString(s)
+	w.b.WriteString("\n")
+}
+
+// lineIf writes the given line when the given condition is true.
+func (w *queryWriter) lineIf(condition bool, s string) {
+	if condition {
+		w.line(s)
+	}
+}
+
+// open writes the given selection opening line and indents the following lines.
+func (w *queryWriter) open(s string) {
+	w.line(s + " {")
+	w.depth++
+}
+
+// close writes the selection closing line.
+func (w *queryWriter) close() {
+	w.depth--
+	w.line("}")
+}
+
+// blank writes an empty line.
+func (w *queryWriter) blank() {
+	w.b.WriteString("\n")
+}
+
+// String returns the written lines.
+func (w *queryWriter) String() string {
+	return w.b.String()
+}
@@ -0,0 +1,136 @@
+package introspection
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditionQuery(t *testing.T) {
+	tests := []struct {
+		subTestName      string
+		edition          Edition
+		expectedFields   []string
+		unexpectedFields []string
+	}{
+		{
+			subTestName:      "Handles June2018 edition",
+			edition:          June2018Edition,
+			expectedFields:   []string{"description", "args {"},
+			unexpectedFields: []string{"specifiedByURL", "isRepeatable", "isOneOf", "args(includeDeprecated: true)"},
+		},
+		{
+			subTestName:      "Handles October2021 edition",
+			edition:          October2021Edition,
+			expectedFields:   []string{"specifiedByURL", "isRepeatable", "    description\n    queryType"},
+			unexpectedFields: []string{"isOneOf", "inputFields(includeDeprecated: true)"},
+		},
+		{
+			subTestName: "Handles draft edition",
+			edition:     DraftEdition,
+			expectedFields: []string{
+				"specifiedByURL", "isRepeatable", "isOneOf", "inputFields(includeDeprecated: true)",
+				"  defaultValue\n  isDeprecated\n  deprecationReason\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			query := EditionQuery(tt.edition)
+
+			assert.True(t, strings.HasPrefix(query, "query IntrospectionQuery {\n"))
+
+			for _, field := range tt.expectedFields {
+				assert.Contains(t, query, field)
+			}
+
+			for _, field := range tt.unexpectedFields {
+				assert.NotContains(t, query, field)
+			}
+
+			assert.Equal(t, strings.Count(query, "{"), strings.Count(query, "}"))
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		subTestName         string
+		opts                Options
+		expectedTypeDepth   int
+		expectedDescription bool
+	}{
+		{
+			subTestName:         "Handles default type depth",
+			opts:                Options{Descriptions: true},
+			expectedTypeDepth:   DefaultTypeDepth,
+			expectedDescription: true,
+		},
+		{
+			subTestName:         "Handles custom type depth",
+			opts:                Options{TypeDepth: 3},
+			expectedTypeDepth:   3,
+			expectedDescription: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			query := Query(tt.opts)
+
+			assert.Equal(t, tt.expectedTypeDepth, strings.Count(query, "ofType {"))
+			assert.Equal(t, tt.expectedDescription, strings.Contains(query, "description"))
+		})
+	}
+}
+
+func TestParseEdition(t *testing.T) {
+	tests := []struct {
+		subTestName   string
+		name          string
+		expected      Edition
+		expectedError bool
+	}{
+		{
+			subTestName: "Handles June2018",
+			name:        "June2018",
+			expected:    June2018Edition,
+		},
+		{
+			subTestName: "Handles October2021",
+			name:        "october2021",
+			expected:    October2021Edition,
+		},
+		{
+			subTestName: "Handles draft",
+			name:        "draft",
+			expected:    DraftEdition,
+		},
+		{
+			subTestName:   "Handles unknown edition",
+			name:          "May2025",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			actual, err := ParseEdition(tt.name)
+			if tt.expectedError {
+				if err == nil {
+					t.Fatalf("expected: error, got: %v", actual)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected: %v, got: %v", tt.expected, err)
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
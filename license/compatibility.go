@@ -0,0 +1,107 @@
+package license
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// compatibleLicenses are the licenses an implementation can use, keyed by the license of the reference
+// implementation it is derived from. A license is always compatible with itself.
+var compatibleLicenses = map[string][]string{
+	"MIT":           permissiveAndCopyleft,
+	"ISC":           permissiveAndCopyleft,
+	"BSD-2-Clause":  permissiveAndCopyleft,
+	"BSD-3-Clause":  permissiveAndCopyleft,
+	"Unlicense":     permissiveAndCopyleft,
+	"Apache-2.0":    {"Apache-2.0", "MPL-2.0", "LGPL-3.0-only", "GPL-3.0-only"},
+	"MPL-2.0":       {"MPL-2.0", "LGPL-2.1-only", "LGPL-3.0-only", "GPL-2.0-only", "GPL-3.0-only"},
+	"LGPL-2.1-only": {"LGPL-2.1-only", "GPL-2.0-only"},
+	"LGPL-3.0-only": {"LGPL-3.0-only", "GPL-3.0-only"},
+	"GPL-2.0-only":  {"GPL-2.0-only"},
+	"GPL-3.0-only":  {"GPL-3.0-only"},
+}
+
+// permissiveAndCopyleft are all the licenses of the license templates.
+var permissiveAndCopyleft = []string{
+	"MIT", "ISC", "BSD-2-Clause", "BSD-3-Clause", "Unlicense", "Apache-2.0",
+	"MPL-2.0", "LGPL-2.1-only", "LGPL-3.0-only", "GPL-2.0-only", "GPL-3.0-only",
+}
+
+// Report represents the license compatibility report of an implementation with the reference implementation.
+type Report struct {
+	// Reference is the detected license of the reference implementation.
+	Reference *Detection
+
+	// Implementation is the detected license of the implementation.
+	Implementation *Detection
+
+	// IsCompatible represents whether or not the implementation license is compatible with the reference license.
+	IsCompatible bool
+
+	// Reason is the explanation of the compatibility result.
+	Reason string
+
+	// Similarity is the similarity score of the implementation license file with the reference license file,
+	// from 0 to 1, zero when either code repository has no license file.
+	Similarity float64
+}
+
+// Compare detects the licenses of the given reference and implementation code repositories directories,
+// and reports whether or not the implementation license is compatible with the reference license.
+func (d *Detector) Compare(referenceDir string, implementationDir string) (*Report, error) {
+	reference, err := d.Detect(referenceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	implementation, err := d.Detect(implementationDir)
+	if err != nil {
+		return nil, err
+	}
+
+	isCompatible, reason := Compatible(reference.ID, implementation.ID)
+
+	return &Report{
+		Reference:      reference,
+		Implementation: implementation,
+		IsCompatible:   isCompatible,
+		Reason:         reason,
+		Similarity:     similarity(reference.bigrams, implementation.bigrams),
+	}, nil
+}
+
+// Compatible returns whether or not an implementation under the given implementation license can be derived
+// from a reference implementation under the given reference license, and the explanation of the result.
+func Compatible(referenceID string, implementationID string) (bool, string) {
+	if referenceID == UnknownID || implementationID == UnknownID {
+		return false, "unknown license"
+	}
+
+	if referenceID == implementationID {
+		return true, "same license"
+	}
+
+	for _, id := range compatibleLicenses[referenceID] {
+		if id == implementationID {
+			return true, fmt.Sprintf("%s code can be used under %s", referenceID, implementationID)
+		}
+	}
+
+	return false, fmt.Sprintf("%s code can not be used under %s", referenceID, implementationID)
+}
+
+// Metrics returns the report as formatted table values: the implementation license, the similarity of its
+// license file with the reference license file and the compatibility result.
+func (r *Report) Metrics() []types.Metric {
+	result := "❌"
+	if r.IsCompatible {
+		result = "✅"
+	}
+
+	return []types.Metric{
+		{Name: "License", Value: r.Implementation.ID},
+		{Name: "License Similarity", Value: fmt.Sprintf("%.0f%%", r.Similarity*100)},
+		{Name: "License Compatibility", Value: result + " " + r.Reason},
+	}
+}
@@ -0,0 +1,86 @@
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		subTestName      string
+		referenceID      string
+		implementationID string
+		expected         bool
+	}{
+		{
+			subTestName:      "Handles same license",
+			referenceID:      "MIT",
+			implementationID: "MIT",
+			expected:         true,
+		},
+		{
+			subTestName:      "Handles permissive reference license",
+			referenceID:      "MIT",
+			implementationID: "Apache-2.0",
+			expected:         true,
+		},
+		{
+			subTestName:      "Handles copyleft reference license",
+			referenceID:      "GPL-3.0-only",
+			implementationID: "MIT",
+			expected:         false,
+		},
+		{
+			subTestName:      "Handles apache reference license",
+			referenceID:      "Apache-2.0",
+			implementationID: "GPL-2.0-only",
+			expected:         false,
+		},
+		{
+			subTestName:      "Handles unknown license",
+			referenceID:      "MIT",
+			implementationID: UnknownID,
+			expected:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			isCompatible, reason := Compatible(tt.referenceID, tt.implementationID)
+
+			assert.Equal(t, tt.expected, isCompatible)
+			assert.NotEmpty(t, reason)
+		})
+	}
+}
+
+func TestDetectorCompare(t *testing.T) {
+	refDir := testDir(t, map[string]string{"LICENSE": graphqlJSLicense})
+	implDir := testDir(t, map[string]string{"LICENSE": testTemplate(t, "BSD-3-Clause")})
+
+	report, err := New().Compare(refDir, implDir)
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.True(t, report.IsCompatible)
+	assert.Equal(t, []types.Metric{
+		{Name: "License", Value: "BSD-3-Clause"},
+		{Name: "License Similarity", Value: "23%"},
+		{Name: "License Compatibility", Value: "✅ MIT code can be used under BSD-3-Clause"},
+	}, report.Metrics())
+
+	report, err = New().Compare(refDir, refDir)
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, []types.Metric{
+		{Name: "License", Value: "MIT"},
+		{Name: "License Similarity", Value: "100%"},
+		{Name: "License Compatibility", Value: "✅ same license"},
+	}, report.Metrics())
+}
@@ -0,0 +1,210 @@
+// package license detects the licenses of the cloned code repositories.
+package license
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// UnknownID is the identifier of the licenses that do not match any license template.
+const UnknownID = "NOASSERTION"
+
+// defaultThreshold is the minimum similarity score of a license text with a license template.
+const defaultThreshold = 0.8
+
+// templatesFS are the embedded license templates, named by their SPDX identifier, eg. `MIT.txt`.
+//
+//go:embed templates/*.txt
+var templatesFS embed.FS
+
+// licenseFileNames are the lower cased name prefixes of the license files.
+var licenseFileNames = []string{"license", "licence", "copying", "unlicense"}
+
+// placeholderRegexp matches the template placeholders, eg. `<copyright holders>`.
+var placeholderRegexp = regexp.MustCompile(`<[^<>]*>`)
+
+// wordRegexp matches the words of a license text.
+var wordRegexp = regexp.MustCompile(`[a-z0-9]+`)
+
+// Detector represents the component that detects the licenses, using normalized text matching
+// against embedded license templates.
+type Detector struct {
+	// templates are the license templates, sorted by SPDX identifier.
+	templates []template
+}
+
+// template represents a license template.
+type template struct {
+	// id is the SPDX identifier of the license.
+	id string
+
+	// bigrams are the word bigrams of the normalized license text.
+	bigrams map[string]int
+}
+
+// New returns a pointer to a Detector struct using the embedded license templates.
+func New() *Detector {
+	entries, err := templatesFS.ReadDir("templates")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read the license templates: %v", err))
+	}
+
+	templates := []template{}
+
+	for _, entry := range entries {
+		content, err := templatesFS.ReadFile(path.Join("templates", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("failed to read the license template %q: %v", entry.Name(), err))
+		}
+
+		templates = append(templates, template{
+			id:      strings.TrimSuffix(entry.Name(), ".txt"),
+			bigrams: bigrams(normalize(placeholderRegexp.ReplaceAllString(string(content), " "))),
+		})
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].id < templates[j].id
+	})
+
+	return &Detector{templates: templates}
+}
+
+// IDs returns the SPDX identifiers of the license templates.
+func (d *Detector) IDs() []string {
+	ids := []string{}
+
+	for _, t := range d.templates {
+		ids = append(ids, t.id)
+	}
+
+	return ids
+}
+
+// Detection represents the detected license of a code repository.
+type Detection struct {
+	// ID is the SPDX identifier of the license, `NOASSERTION` when it is unknown.
+	ID string
+
+	// File is the path of the license file, empty when the code repository has no license file.
+	File string
+
+	// Confidence is the similarity score of the license file with the license template, from 0 to 1.
+	Confidence float64
+
+	// bigrams are the word bigrams of the normalized license file text.
+	bigrams map[string]int
+}
+
+// Detect detects the license of the code repository of the given directory from its top level license files,
+// eg. `LICENSE`, `LICENSE.md` or `COPYING`. The most confident license file is used.
+func (d *Detector) Detect(dir string) (*Detection, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the repository directory: %w", err)
+	}
+
+	detection := &Detection{ID: UnknownID}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !isLicenseFileName(entry.Name()) {
+			continue
+		}
+
+		file := filepath.Join(dir, entry.Name())
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the license file: %w", err)
+		}
+
+		id, confidence := d.Classify(string(content))
+		if detection.File == "" || confidence > detection.Confidence {
+			detection = &Detection{ID: id, File: file, Confidence: confidence, bigrams: bigrams(normalize(string(content)))}
+		}
+	}
+
+	return detection, nil
+}
+
+// Classify returns the SPDX identifier of the given license text and its similarity score,
+// `NOASSERTION` when no license template is similar enough.
+func (d *Detector) Classify(text string) (string, float64) {
+	textBigrams := bigrams(normalize(text))
+
+	id := UnknownID
+	best := 0.0
+
+	for _, t := range d.templates {
+		score := similarity(textBigrams, t.bigrams)
+		if score > best {
+			id = t.id
+			best = score
+		}
+	}
+
+	if best < defaultThreshold {
+		return UnknownID, best
+	}
+
+	return id, best
+}
+
+// isLicenseFileName returns whether the given file name is a license file name, eg. `LICENSE-MIT`.
+func isLicenseFileName(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, prefix := range licenseFileNames {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize returns the words of the given license text: the text is lower cased and the punctuation,
+// the quotes and the line breaks are ignored.
+func normalize(text string) []string {
+	text = strings.ToLower(text)
+	text = strings.NewReplacer("&", " and ", "licence", "license").Replace(text)
+
+	return wordRegexp.FindAllString(text, -1)
+}
+
+// bigrams returns the counts of the consecutive word pairs of the given words.
+func bigrams(words []string) map[string]int {
+	result := map[string]int{}
+
+	for i := 0; i+1 < len(words); i++ {
+		result[words[i]+" "+words[i+1]]++
+	}
+
+	return result
+}
+
+// similarity returns the Sørensen-Dice coefficient of the given bigram multisets, from 0 to 1.
+func similarity(a map[string]int, b map[string]int) float64 {
+	total, common := 0, 0
+
+	for bigram, count := range a {
+		total += count
+		common += min(count, b[bigram])
+	}
+
+	for _, count := range b {
+		total += count
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return 2 * float64(common) / float64(total)
+}
@@ -0,0 +1,177 @@
+package license
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// graphqlJSLicense is the license of graphql-js.
+const graphqlJSLicense = `MIT License
+
+Copyright (c) GraphQL Contributors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+`
+
+func TestNew(t *testing.T) {
+	expected := []string{
+		"Apache-2.0", "BSD-2-Clause", "BSD-3-Clause", "GPL-2.0-only", "GPL-3.0-only", "ISC",
+		"LGPL-2.1-only", "LGPL-3.0-only", "MIT", "MPL-2.0", "Unlicense",
+	}
+
+	assert.Equal(t, expected, New().IDs())
+}
+
+func TestDetectorClassify(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		text        string
+		expected    string
+	}{
+		{
+			subTestName: "Handles MIT license",
+			text:        graphqlJSLicense,
+			expected:    "MIT",
+		},
+		{
+			subTestName: "Handles reflowed markdown MIT license",
+			text:        "# The MIT License (MIT)\n\n" + strings.Join(strings.Fields(graphqlJSLicense), " "),
+			expected:    "MIT",
+		},
+		{
+			subTestName: "Handles BSD 2-Clause license",
+			text:        testTemplate(t, "BSD-2-Clause"),
+			expected:    "BSD-2-Clause",
+		},
+		{
+			subTestName: "Handles BSD 3-Clause license",
+			text:        strings.ReplaceAll(testTemplate(t, "BSD-3-Clause"), "<year>, <copyright holders>", "2015, Acme"),
+			expected:    "BSD-3-Clause",
+		},
+		{
+			subTestName: "Handles Apache license without appendix",
+			text:        strings.Split(testTemplate(t, "Apache-2.0"), "APPENDIX")[0],
+			expected:    "Apache-2.0",
+		},
+		{
+			subTestName: "Handles unknown license",
+			text:        "All rights reserved. Do not copy.",
+			expected:    UnknownID,
+		},
+	}
+
+	d := New()
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			id, confidence := d.Classify(tt.text)
+
+			assert.Equal(t, tt.expected, id)
+
+			if tt.expected != UnknownID {
+				assert.GreaterOrEqual(t, confidence, defaultThreshold)
+			}
+		})
+	}
+}
+
+func TestDetectorDetect(t *testing.T) {
+	tests := []struct {
+		subTestName  string
+		files        map[string]string
+		expectedID   string
+		expectedFile string
+	}{
+		{
+			subTestName:  "Handles license file",
+			files:        map[string]string{"LICENSE": graphqlJSLicense, "README.md": "# graphql-js"},
+			expectedID:   "MIT",
+			expectedFile: "LICENSE",
+		},
+		{
+			subTestName: "Handles most confident license file",
+			files: map[string]string{
+				"COPYING":     "See LICENSE.md",
+				"LICENSE.md":  graphqlJSLicense,
+				"license.txt": "Licensed under the terms of the LICENSE.md file.",
+			},
+			expectedID:   "MIT",
+			expectedFile: "LICENSE.md",
+		},
+		{
+			subTestName: "Handles missing license file",
+			files:       map[string]string{"README.md": "# graphql-js"},
+			expectedID:  UnknownID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			dir := testDir(t, tt.files)
+
+			detection, err := New().Detect(dir)
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.Equal(t, tt.expectedID, detection.ID)
+
+			expectedFile := ""
+			if tt.expectedFile != "" {
+				expectedFile = filepath.Join(dir, tt.expectedFile)
+			}
+
+			assert.Equal(t, expectedFile, detection.File)
+		})
+	}
+
+	_, err := New().Detect(filepath.Join(t.TempDir(), "missing"))
+	assert.NotNil(t, err)
+}
+
+// testTemplate returns the embedded license template of the given SPDX identifier.
+func testTemplate(t *testing.T, id string) string {
+	t.Helper()
+
+	content, err := templatesFS.ReadFile("templates/" + id + ".txt")
+	if err != nil {
+		t.Fatalf("failed to read template: %v", err)
+	}
+
+	return string(content)
+}
+
+// testDir returns a temporary directory with the given files content, keyed by their name.
+func testDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	return dir
+}
@@ -0,0 +1,278 @@
+// package matcher maps the reference implementation test names to the implementation test names.
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// defaultThreshold is the default minimum similarity score of a match.
+const defaultThreshold = 0.6
+
+// Matcher represents the component that maps the reference test names to the implementation test names.
+type Matcher struct {
+}
+
+// New returns a pointer to a Matcher struct.
+func New() *Matcher {
+	return &Matcher{}
+}
+
+// MatchParams represents the parameters of the match method.
+type MatchParams struct {
+	// ReferenceTestNames are the test names of the reference implementation, eg. graphql-js.
+	ReferenceTestNames []string
+
+	// ImplementationTestNames are the test names of the implementation, eg. graphql-go.
+	ImplementationTestNames []string
+
+	// Overrides are the manual mappings that replace the similarity matching of their reference test names.
+	Overrides Overrides
+
+	// Threshold is the minimum similarity score of a match, from 0 to 1, defaults to 0.6.
+	Threshold float64
+}
+
+// threshold returns the threshold parameter, or the default threshold when it is not set.
+func (p *MatchParams) threshold() float64 {
+	if p.Threshold <= 0 {
+		return defaultThreshold
+	}
+
+	return p.Threshold
+}
+
+// Overrides are the manual mappings of the reference test names to their implementation test names,
+// an empty list of implementation test names marks the reference test name as not implemented.
+type Overrides map[string][]string
+
+// ReadOverrides reads the overrides JSON file at the given path,
+// eg. `{"Lexer lexes strings": ["TestLexer/lexes_strings"]}`.
+func ReadOverrides(path string) (Overrides, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the overrides: %w", err)
+	}
+
+	overrides := Overrides{}
+	if err := json.Unmarshal(content, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to decode the overrides: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// Result represents the result of the mapping of the reference test names.
+type Result struct {
+	// Mappings are the mappings of the reference test names, in the reference test names order.
+	Mappings []Mapping
+}
+
+// Mapping represents the mapping of a reference test name to zero or more implementation test names.
+type Mapping struct {
+	// ReferenceTestName is the reference test name.
+	ReferenceTestName string
+
+	// Matches are the matching implementation test names, sorted by decreasing score.
+	Matches []Match
+}
+
+// Match represents an implementation test name matching a reference test name.
+type Match struct {
+	// TestName is the implementation test name.
+	TestName string
+
+	// Score is the confidence score of the match, from 0 to 1.
+	Score float64
+
+	// IsOverride represents whether or not the match comes from the overrides.
+	IsOverride bool
+}
+
+// Matched returns the number of reference test names with at least one match.
+func (r *Result) Matched() int {
+	matched := 0
+
+	for _, m := range r.Mappings {
+		if len(m.Matches) > 0 {
+			matched++
+		}
+	}
+
+	return matched
+}
+
+// Parity returns the test coverage parity ratio, from 0 to 1,
+// which is the ratio of the reference test names with at least one match.
+func (r *Result) Parity() float64 {
+	if len(r.Mappings) == 0 {
+		return 0
+	}
+
+	return float64(r.Matched()) / float64(len(r.Mappings))
+}
+
+// ParityString returns the test coverage parity as a percentage, eg. `42.50%`.
+func (r *Result) ParityString() string {
+	return fmt.Sprintf("%.2f%%", r.Parity()*100)
+}
+
+// Match maps every reference test name to the implementation test names whose similarity score
+// reaches the threshold, the overridden reference test names use their overrides instead.
+func (m *Matcher) Match(params *MatchParams) (*Result, error) {
+	threshold := params.threshold()
+	if threshold > 1 {
+		return nil, fmt.Errorf("failed to match test names: unexpected threshold: %v", threshold)
+	}
+
+	candidates := []testNameTokens{}
+	for _, name := range params.ImplementationTestNames {
+		candidates = append(candidates, testNameTokens{name: name, tokens: tokenize(name)})
+	}
+
+	result := &Result{}
+
+	for _, refName := range params.ReferenceTestNames {
+		mapping := Mapping{ReferenceTestName: refName, Matches: []Match{}}
+
+		if overrides, ok := params.Overrides[refName]; ok {
+			for _, name := range overrides {
+				mapping.Matches = append(mapping.Matches, Match{TestName: name, Score: 1, IsOverride: true})
+			}
+
+			result.Mappings = append(result.Mappings, mapping)
+			continue
+		}
+
+		refTokens := tokenize(refName)
+
+		for _, c := range candidates {
+			score := similarity(refTokens, c.tokens)
+			if score >= threshold {
+				mapping.Matches = append(mapping.Matches, Match{TestName: c.name, Score: score})
+			}
+		}
+
+		sort.SliceStable(mapping.Matches, func(i, j int) bool {
+			return mapping.Matches[i].Score > mapping.Matches[j].Score
+		})
+
+		result.Mappings = append(result.Mappings, mapping)
+	}
+
+	return result, nil
+}
+
+// testNameTokens represents a test name with its normalized tokens.
+type testNameTokens struct {
+	// name is the test name.
+	name string
+
+	// tokens are the normalized tokens of the test name.
+	tokens []string
+}
+
+// ignoredTokens are the tokens that do not contribute to the similarity of the test names.
+var ignoredTokens = map[string]bool{
+	"test":   true,
+	"tests":  true,
+	"handle": true,
+	"the":    true,
+	"a":      true,
+	"an":     true,
+}
+
+// tokenize returns the normalized tokens of the given test name: the name is split on the separators,
+// the non alphanumeric characters and the camel case boundaries, then the tokens are lower cased and
+// their plural suffix is trimmed, eg. `TestLexer/lexes_strings` gives `lexer`, `lexe` and `string`.
+func tokenize(name string) []string {
+	tokens := []string{}
+	word := []rune{}
+
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+
+		token := normalizeToken(string(word))
+		if !ignoredTokens[token] {
+			tokens = append(tokens, token)
+		}
+
+		word = word[:0]
+	}
+
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+
+		if i > 0 && len(word) > 0 && isCamelBoundary(runes, i) {
+			flush()
+		}
+
+		word = append(word, r)
+	}
+
+	flush()
+
+	return tokens
+}
+
+// isCamelBoundary returns whether a new camel case word starts at the given index, eg. `Lexer` in `TestLexer`,
+// `Parse` in `HTTPParse` or `2` in `June2018`.
+func isCamelBoundary(runes []rune, i int) bool {
+	prev, r := runes[i-1], runes[i]
+
+	switch {
+	case unicode.IsLower(prev) && unicode.IsUpper(r):
+		return true
+	case unicode.IsLetter(prev) != unicode.IsLetter(r):
+		return true
+	case unicode.IsUpper(prev) && unicode.IsUpper(r) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizeToken returns the given token lower cased and without its plural suffix.
+func normalizeToken(token string) string {
+	token = strings.ToLower(token)
+
+	if len(token) > 3 && strings.HasSuffix(token, "s") && !strings.HasSuffix(token, "ss") {
+		token = strings.TrimSuffix(token, "s")
+	}
+
+	return token
+}
+
+// similarity returns the Sørensen-Dice coefficient of the given token multisets, from 0 to 1.
+func similarity(a []string, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	counts := map[string]int{}
+	for _, token := range a {
+		counts[token]++
+	}
+
+	common := 0
+
+	for _, token := range b {
+		if counts[token] > 0 {
+			counts[token]--
+			common++
+		}
+	}
+
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
@@ -0,0 +1,183 @@
+package matcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	m := New()
+
+	if m == nil {
+		t.Fatalf("expected: %+v, got: nil", &Matcher{})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		name        string
+		expected    []string
+	}{
+		{
+			subTestName: "Handles graphql-js test name",
+			name:        "Lexer disallows uncommon control characters",
+			expected:    []string{"lexer", "disallow", "uncommon", "control", "character"},
+		},
+		{
+			subTestName: "Handles go subtest name",
+			name:        "TestLexer/disallows_uncommon_control_characters",
+			expected:    []string{"lexer", "disallow", "uncommon", "control", "character"},
+		},
+		{
+			subTestName: "Handles camel case, acronyms and digits",
+			name:        "TestParseHTTPQuery_June2018",
+			expected:    []string{"parse", "http", "query", "june", "2018"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tokenize(tt.name))
+		})
+	}
+}
+
+func TestMatcherMatch(t *testing.T) {
+	refNames := []string{
+		"Lexer disallows uncommon control characters",
+		"Lexer lexes strings",
+		"Parser parses variable definitions",
+		"Schema Printer prints string field",
+	}
+
+	implNames := []string{
+		"TestLexer_DisallowsUncommonControlCharacters",
+		"TestLexer/lexes_strings",
+		"TestLexesStrings",
+		"TestParser/parses_fragments",
+		"TestExecutor",
+	}
+
+	result, err := New().Match(&MatchParams{
+		ReferenceTestNames:      refNames,
+		ImplementationTestNames: implNames,
+		Overrides: Overrides{
+			"Schema Printer prints string field": {"TestPrinter/string_field"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	expected := &Result{
+		Mappings: []Mapping{
+			{
+				ReferenceTestName: "Lexer disallows uncommon control characters",
+				Matches: []Match{
+					{TestName: "TestLexer_DisallowsUncommonControlCharacters", Score: 1},
+				},
+			},
+			{
+				ReferenceTestName: "Lexer lexes strings",
+				Matches: []Match{
+					{TestName: "TestLexer/lexes_strings", Score: 1},
+					{TestName: "TestLexesStrings", Score: 0.8},
+				},
+			},
+			{
+				ReferenceTestName: "Parser parses variable definitions",
+				Matches:           []Match{},
+			},
+			{
+				ReferenceTestName: "Schema Printer prints string field",
+				Matches: []Match{
+					{TestName: "TestPrinter/string_field", Score: 1, IsOverride: true},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, 3, result.Matched())
+	assert.Equal(t, 0.75, result.Parity())
+	assert.Equal(t, "75.00%", result.ParityString())
+}
+
+func TestMatcherMatchThreshold(t *testing.T) {
+	tests := []struct {
+		subTestName     string
+		threshold       float64
+		expectedMatches int
+		expectedErr     bool
+	}{
+		{
+			subTestName:     "Handles default threshold",
+			expectedMatches: 0,
+		},
+		{
+			subTestName:     "Handles lower threshold",
+			threshold:       0.4,
+			expectedMatches: 1,
+		},
+		{
+			subTestName: "Handles unexpected threshold",
+			threshold:   1.5,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			result, err := New().Match(&MatchParams{
+				ReferenceTestNames:      []string{"Parser parses variable definitions"},
+				ImplementationTestNames: []string{"TestParser/parses_fragments"},
+				Threshold:               tt.threshold,
+			})
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.Equal(t, tt.expectedMatches, len(result.Mappings[0].Matches))
+		})
+	}
+}
+
+func TestResultParity(t *testing.T) {
+	result := &Result{}
+
+	assert.Equal(t, float64(0), result.Parity())
+	assert.Equal(t, "0.00%", result.ParityString())
+}
+
+func TestReadOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+
+	content := `{"Lexer lexes strings": ["TestLexer/lexes_strings"], "Lexer lexes comments": []}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	overrides, err := ReadOverrides(path)
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	expected := Overrides{
+		"Lexer lexes strings":  {"TestLexer/lexes_strings"},
+		"Lexer lexes comments": {},
+	}
+
+	assert.Equal(t, expected, overrides)
+
+	_, err = ReadOverrides(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,237 @@
+// package metrics computes the git history metrics of the cloned code repositories.
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/graphql-go/compatibility-base/tags"
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// defaultDays is the default number of days of the recent commits.
+const defaultDays = 90
+
+// dateLayout is the layout of the dates of the metrics values.
+const dateLayout = "2006-01-02"
+
+// notAvailable is the value of the metrics that can not be computed.
+const notAvailable = "N/A"
+
+// Collector represents the component that computes the git history metrics of cloned code repositories,
+// without network access.
+type Collector struct {
+}
+
+// New returns a pointer to a Collector struct.
+func New() *Collector {
+	return &Collector{}
+}
+
+// CollectParams represents the parameters of the collect method.
+type CollectParams struct {
+	// Dir is the directory of the cloned git code repository.
+	Dir string
+
+	// ReferenceName is the reference name the metrics are computed at, eg. a tag, defaults to the HEAD.
+	ReferenceName string
+
+	// Days is the number of days of the recent commits, defaults to 90.
+	Days int
+
+	// Now is the time the recent commits are counted from, defaults to the current time.
+	Now time.Time
+}
+
+// History represents the git history metrics of a code repository.
+type History struct {
+	// LastCommitDate is the committer date of the last commit at the reference name.
+	LastCommitDate time.Time
+
+	// Commits is the number of commits reachable from the reference name.
+	Commits int
+
+	// Contributors is the number of distinct commit authors, identified by their email.
+	Contributors int
+
+	// Days is the number of days of the recent commits.
+	Days int
+
+	// RecentCommits is the number of commits of the last days.
+	RecentCommits int
+
+	// Releases is the number of release tags, which are the stable semantic versions and specification editions.
+	Releases int
+
+	// ReleaseCadence is the average duration between two consecutive releases, zero with less than two releases.
+	ReleaseCadence time.Duration
+}
+
+// Metrics returns the metrics of the git history as formatted table values.
+func (h *History) Metrics() []types.Metric {
+	cadence := notAvailable
+	if h.ReleaseCadence > 0 {
+		cadence = fmt.Sprintf("%.1f days", h.ReleaseCadence.Hours()/24)
+	}
+
+	return []types.Metric{
+		{Name: "Last Commit Date", Value: h.LastCommitDate.UTC().Format(dateLayout)},
+		{Name: "Number Of Contributors", Value: strconv.Itoa(h.Contributors)},
+		{Name: fmt.Sprintf("Number Of Commits In Last %d Days", h.Days), Value: strconv.Itoa(h.RecentCommits)},
+		{Name: "Release Cadence", Value: cadence},
+	}
+}
+
+// Collect computes the git history metrics of the given cloned code repository at the given reference name.
+// Shallow clones only contribute their available history.
+func (c *Collector) Collect(params *CollectParams) (*History, error) {
+	repo, err := git.PlainOpen(params.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a git repository: %w", err)
+	}
+
+	revision := params.ReferenceName
+	if revision == "" {
+		revision = plumbing.HEAD.String()
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve reference %q: %w", revision, err)
+	}
+
+	days := params.Days
+	if days <= 0 {
+		days = defaultDays
+	}
+
+	now := params.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	history := &History{Days: days}
+
+	if err := c.collectCommits(repo, *hash, now.AddDate(0, 0, -days), history); err != nil {
+		return nil, err
+	}
+
+	if err := c.collectReleases(repo, history); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// collectCommits computes the commits metrics of the history reachable from the given commit hash,
+// the commits after the given time are the recent commits.
+func (c *Collector) collectCommits(repo *git.Repository, hash plumbing.Hash, since time.Time, history *History) error {
+	head, err := repo.CommitObject(hash)
+	if err != nil {
+		return fmt.Errorf("failed to get the commit %s: %w", hash, err)
+	}
+
+	history.LastCommitDate = head.Committer.When
+
+	iter, err := repo.Log(&git.LogOptions{From: hash})
+	if err != nil {
+		return fmt.Errorf("failed to get the commit history: %w", err)
+	}
+	defer iter.Close()
+
+	authors := map[string]bool{}
+
+	err = iter.ForEach(func(commit *object.Commit) error {
+		history.Commits++
+
+		author := strings.ToLower(commit.Author.Email)
+		if author == "" {
+			author = commit.Author.Name
+		}
+
+		authors[author] = true
+
+		if commit.Committer.When.After(since) {
+			history.RecentCommits++
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, plumbing.ErrObjectNotFound) {
+		return fmt.Errorf("failed to walk the commit history: %w", err)
+	}
+
+	history.Contributors = len(authors)
+
+	return nil
+}
+
+// collectReleases computes the releases metrics from the release tags dates,
+// which are the committer dates of their commits.
+func (c *Collector) collectReleases(repo *git.Repository, history *History) error {
+	refs, err := repo.Tags()
+	if err != nil {
+		return fmt.Errorf("failed to list the tags: %w", err)
+	}
+
+	dates := []time.Time{}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		tag, ok := tags.Parse(ref.Name().Short())
+		if !ok || !tag.IsStable() {
+			return nil
+		}
+
+		commit, err := tagCommit(repo, ref.Hash())
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		dates = append(dates, commit.Committer.When)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the release dates: %w", err)
+	}
+
+	history.Releases = len(dates)
+
+	if len(dates) < 2 {
+		return nil
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	history.ReleaseCadence = dates[len(dates)-1].Sub(dates[0]) / time.Duration(len(dates)-1)
+
+	return nil
+}
+
+// tagCommit returns the commit the given tag hash points to, annotated tags are peeled.
+func tagCommit(repo *git.Repository, hash plumbing.Hash) (*object.Commit, error) {
+	tag, err := repo.TagObject(hash)
+	if err == nil {
+		return tag.Commit()
+	}
+
+	if !errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, err
+	}
+
+	return repo.CommitObject(hash)
+}
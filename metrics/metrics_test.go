@@ -0,0 +1,221 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestNew(t *testing.T) {
+	c := New()
+
+	if c == nil {
+		t.Fatalf("expected: %+v, got: nil", &Collector{})
+	}
+}
+
+func TestCollectorCollect(t *testing.T) {
+	dir := newTestRepo(t)
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		subTestName   string
+		referenceName string
+		days          int
+		expected      *History
+	}{
+		{
+			subTestName: "Handles head with default days",
+			expected: &History{
+				LastCommitDate: time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC),
+				Commits:        4,
+				Contributors:   3,
+				Days:           90,
+				RecentCommits:  2,
+				Releases:       3,
+				ReleaseCadence: 45 * 24 * time.Hour / 2,
+			},
+		},
+		{
+			subTestName:   "Handles tag with custom days",
+			referenceName: "v0.1.0",
+			days:          365,
+			expected: &History{
+				LastCommitDate: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+				Commits:        2,
+				Contributors:   2,
+				Days:           365,
+				RecentCommits:  2,
+				Releases:       3,
+				ReleaseCadence: 45 * 24 * time.Hour / 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			history, err := New().Collect(&CollectParams{
+				Dir:           dir,
+				ReferenceName: tt.referenceName,
+				Days:          tt.days,
+				Now:           now,
+			})
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.True(t, tt.expected.LastCommitDate.Equal(history.LastCommitDate))
+
+			history.LastCommitDate = tt.expected.LastCommitDate
+			assert.Equal(t, tt.expected, history)
+		})
+	}
+}
+
+func TestCollectorCollectErrors(t *testing.T) {
+	tests := []struct {
+		subTestName   string
+		dir           string
+		referenceName string
+	}{
+		{
+			subTestName: "Handles missing repository",
+			dir:         t.TempDir(),
+		},
+		{
+			subTestName:   "Handles missing reference name",
+			dir:           newTestRepo(t),
+			referenceName: "v9.9.9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			_, err := New().Collect(&CollectParams{Dir: tt.dir, ReferenceName: tt.referenceName})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestHistoryMetrics(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		history     *History
+		expected    []types.Metric
+	}{
+		{
+			subTestName: "Handles release cadence",
+			history: &History{
+				LastCommitDate: time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC),
+				Contributors:   3,
+				Days:           90,
+				RecentCommits:  2,
+				ReleaseCadence: 36 * time.Hour,
+			},
+			expected: []types.Metric{
+				{Name: "Last Commit Date", Value: "2025-02-20"},
+				{Name: "Number Of Contributors", Value: "3"},
+				{Name: "Number Of Commits In Last 90 Days", Value: "2"},
+				{Name: "Release Cadence", Value: "1.5 days"},
+			},
+		},
+		{
+			subTestName: "Handles missing release cadence",
+			history: &History{
+				LastCommitDate: time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC),
+				Days:           30,
+			},
+			expected: []types.Metric{
+				{Name: "Last Commit Date", Value: "2025-02-20"},
+				{Name: "Number Of Contributors", Value: "0"},
+				{Name: "Number Of Commits In Last 30 Days", Value: "0"},
+				{Name: "Release Cadence", Value: "N/A"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.history.Metrics())
+		})
+	}
+}
+
+// newTestRepo creates a local code repository with four commits of three authors, the release tags `v0.1.0`,
+// `v0.2.0` and `October2021`, and the non release tags `v0.3.0-rc.1` and `nightly`.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+
+	commits := []struct {
+		author string
+		date   time.Time
+	}{
+		{author: "alice@example.com", date: time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{author: "Bob@example.com", date: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{author: "bob@example.com", date: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{author: "carol@example.com", date: time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	hashes := []plumbing.Hash{}
+
+	for i, c := range commits {
+		if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(c.date.String()), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+
+		if _, err := w.Add("README.md"); err != nil {
+			t.Fatalf("failed to add file: %v", err)
+		}
+
+		signature := &object.Signature{Name: c.author, Email: c.author, When: c.date}
+
+		hash, err := w.Commit(c.date.String(), &git.CommitOptions{Author: signature, Committer: signature})
+		if err != nil {
+			t.Fatalf("failed to commit %d: %v", i, err)
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	if _, err := repo.CreateTag("v0.1.0", hashes[1], &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "tagger", Email: "tagger@example.com", When: time.Now()},
+		Message: "v0.1.0",
+	}); err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+
+	lightweightTags := map[string]plumbing.Hash{
+		"v0.2.0":      hashes[2],
+		"October2021": hashes[2],
+		"v0.3.0-rc.1": hashes[3],
+		"nightly":     hashes[3],
+	}
+
+	for name, hash := range lightweightTags {
+		if _, err := repo.CreateTag(name, hash, nil); err != nil {
+			t.Fatalf("failed to create tag: %v", err)
+		}
+	}
+
+	return dir
+}
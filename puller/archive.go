@@ -0,0 +1,437 @@
+package puller
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// checksumPrefix is the prefix of the sha256 checksums.
+const checksumPrefix = "sha256:"
+
+// maxSymlinkTargetSize is the maximum size in bytes of the target of an archive symbolic link.
+const maxSymlinkTargetSize = 4096
+
+// ErrChecksumRequired is the error returned when an archive has no checksum and does not opt out of its verification.
+var ErrChecksumRequired = errors.New("archive checksum is required")
+
+// archiveSource represents the source that pulls a code repository from a tar.gz or zip archive,
+// the archive is read from a local path or downloaded from an HTTP server.
+type archiveSource struct {
+}
+
+// Pull downloads and extracts the requested archive, the archive checksum is verified unless the repository
+// opts out of it.
+// Already extracted archives are handled using the existing repository policy.
+// Returns the action that was performed.
+func (s *archiveSource) Pull(ctx context.Context, req *SourceRequest) (PullAction, error) {
+	action := CloneAction
+
+	if req.Repository.Checksum == "" && !req.Repository.InsecureSkipChecksum {
+		return action, fmt.Errorf("failed to pull the archive %q: %w", req.Repository.URL, ErrChecksumRequired)
+	}
+
+	if _, err := os.Stat(req.Dir); err == nil {
+		switch req.Params.ExistingRepoPolicy {
+		case ReusePolicy:
+			return ReuseAction, nil
+		case ReclonePolicy:
+			action = RecloneAction
+		default:
+			action = FetchAction
+		}
+
+		if req.Params.Offline && isHTTPURL(req.Repository.URL) {
+			return ReuseAction, nil
+		}
+	}
+
+	archivePath, err := s.download(ctx, req)
+	if err != nil {
+		return action, err
+	}
+	defer os.Remove(archivePath)
+
+	if err := ctx.Err(); err != nil {
+		return action, err
+	}
+
+	req.Progress(ProgressEvent{Phase: CheckoutPhase})
+
+	if err := s.extract(archivePath, req.Dir); err != nil {
+		return action, err
+	}
+
+	req.Progress(ProgressEvent{Phase: CheckoutPhase, Percentage: 100})
+
+	return action, nil
+}
+
+// download copies the requested archive into a temporary file, verifies its checksum and returns the file path.
+func (s *archiveSource) download(ctx context.Context, req *SourceRequest) (string, error) {
+	body, err := s.open(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(req.Dir), os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create a directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(req.Dir), "."+filepath.Base(req.Dir)+"-*.archive")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temporary file: %w", err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(f, hash), body)
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to download the archive: %w", err)
+	}
+
+	req.Progress(ProgressEvent{Phase: ReceivingPhase, Percentage: 100, Bytes: size})
+
+	if err := verifyChecksum(req.Repository, hash.Sum(nil)); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// open returns the reader of the requested archive, from an HTTP server or a local path.
+// Relative local paths are resolved from the workspace root.
+func (s *archiveSource) open(ctx context.Context, req *SourceRequest) (io.ReadCloser, error) {
+	url := req.Repository.URL
+
+	if !isHTTPURL(url) {
+		path := strings.TrimPrefix(url, "file://")
+		if !filepath.IsAbs(path) && req.Workspace != nil {
+			path = filepath.Join(req.Workspace.Root(), path)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the archive: %w", err)
+		}
+
+		return f, nil
+	}
+
+	if req.Params.Offline {
+		return nil, fmt.Errorf("failed to download the archive %q: %w", url, ErrNotAvailableOffline)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the archive request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the archive: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download the archive: unexpected status code: %d", resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+// extract extracts the given archive into the given directory, replacing its previous content.
+// The single top level directory of the archive, eg. `graphql-js-0.6.0/`, is stripped.
+func (s *archiveSource) extract(archivePath string, dir string) error {
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dir), "."+filepath.Base(dir)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create a temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	isZip, err := isZipArchive(archivePath)
+	if err != nil {
+		return err
+	}
+
+	if isZip {
+		err = extractZip(archivePath, tmpDir)
+	} else {
+		err = extractTarGz(archivePath, tmpDir)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to extract the archive: %w", err)
+	}
+
+	root, err := archiveRoot(tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove the previous archive content: %w", err)
+	}
+
+	if err := os.Rename(root, dir); err != nil {
+		return fmt.Errorf("failed to move the archive content: %w", err)
+	}
+
+	return nil
+}
+
+// isZipArchive returns whether the given archive is a zip archive, using its magic number.
+func isZipArchive(archivePath string) (bool, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to open the archive: %w", err)
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false, fmt.Errorf("failed to read the archive: %w", err)
+	}
+
+	return bytes.Equal(magic, []byte("PK\x03\x04")), nil
+}
+
+// extractTarGz extracts the given tar.gz archive into the given directory.
+func extractTarGz(archivePath string, dir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		path, err := archiveEntryPath(dir, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, os.ModePerm); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(path, header.FileInfo().Mode(), tr); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := writeArchiveSymlink(dir, path, header.Linkname); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip extracts the given zip archive into the given directory.
+func extractZip(archivePath string, dir string) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		path, err := archiveEntryPath(dir, f.Name)
+		if err != nil {
+			return err
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, os.ModePerm); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if f.Mode()&os.ModeSymlink != 0 {
+			if err := extractZipSymlink(dir, path, f); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+
+		err = writeArchiveFile(path, f.Mode(), rc)
+		rc.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractZipSymlink creates the given zip symbolic link entry, whose content is the link target.
+func extractZipSymlink(dir string, path string, f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	target, err := io.ReadAll(io.LimitReader(rc, maxSymlinkTargetSize))
+	if err != nil {
+		return err
+	}
+
+	return writeArchiveSymlink(dir, path, string(target))
+}
+
+// archiveEntryPath returns the extraction path of the given archive entry name,
+// entries escaping the extraction directory are rejected.
+// Entries going through a symbolic link extracted by a previous entry are rejected as well,
+// since the link may resolve outside of the extraction directory.
+func archiveEntryPath(dir string, name string) (string, error) {
+	path := filepath.Join(dir, name)
+
+	if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("unexpected archive entry path: %q", name)
+	}
+
+	current := filepath.Clean(dir)
+
+	for _, part := range strings.Split(strings.TrimPrefix(path, current+string(os.PathSeparator)), string(os.PathSeparator)) {
+		current = filepath.Join(current, part)
+
+		info, err := os.Lstat(current)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("unexpected archive entry path through a symbolic link: %q", name)
+		}
+	}
+
+	return path, nil
+}
+
+// writeArchiveFile writes the given archive entry content into the given path.
+func writeArchiveFile(path string, mode os.FileMode, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeArchiveSymlink creates the given archive symbolic link, links escaping the extraction directory are rejected.
+func writeArchiveSymlink(dir string, path string, target string) error {
+	if filepath.IsAbs(target) {
+		return fmt.Errorf("unexpected archive symbolic link target: %q", target)
+	}
+
+	rel, err := filepath.Rel(dir, filepath.Join(filepath.Dir(path), target))
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("unexpected archive symbolic link target: %q", target)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	return os.Symlink(target, path)
+}
+
+// archiveRoot returns the root directory of the extracted archive,
+// which is the single top level directory when the archive has one.
+func archiveRoot(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the archive content: %w", err)
+	}
+
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+
+	return dir, nil
+}
+
+// verifyChecksum verifies the given sha256 sum against the expected checksum of the given repository,
+// the checksum is not verified when the repository opts out of it.
+func verifyChecksum(r *types.Repository, sum []byte) error {
+	if r.InsecureSkipChecksum {
+		return nil
+	}
+
+	expected := strings.ToLower(strings.TrimPrefix(r.Checksum, checksumPrefix))
+	actual := hex.EncodeToString(sum)
+
+	if expected != actual {
+		return &VerificationError{
+			Repository: r.Name,
+			Subject:    "checksum",
+			Expected:   checksumPrefix + expected,
+			Actual:     checksumPrefix + actual,
+		}
+	}
+
+	return nil
+}
+
+// isHTTPURL returns whether the given URL uses the HTTP or HTTPS scheme.
+func isHTTPURL(url string) bool {
+	return strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")
+}
@@ -0,0 +1,185 @@
+package puller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// defaultSSHUser is the default user of the SSH authentication.
+const defaultSSHUser = "git"
+
+// defaultTokenUser is the default username of the HTTP token authentication, the token is the password.
+const defaultTokenUser = "git"
+
+// errNoCredentials is the error returned when the credential helper does not fill any credentials.
+var errNoCredentials = errors.New("no credentials")
+
+// resolveAuth returns the git authentication method of the given code repository, nil when it has no
+// authentication. The secrets are read from the SSH agent, the key files, the environment or the credential helper,
+// they are never part of the returned errors.
+func resolveAuth(ctx context.Context, r *types.Repository) (transport.AuthMethod, error) {
+	if r.Auth == nil {
+		return nil, nil
+	}
+
+	auth := r.Auth
+
+	switch auth.Type {
+	case types.SSHAgentAuthType:
+		method, err := ssh.NewSSHAgentAuth(defaultValue(auth.Username, defaultSSHUser))
+		if err != nil {
+			return nil, fmt.Errorf("failed to use the ssh agent of repository %q: %w", r.Name, err)
+		}
+
+		return method, nil
+	case types.SSHKeyAuthType:
+		keyPath, err := expandHome(auth.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		passphrase := ""
+		if auth.PassphraseEnv != "" {
+			passphrase = os.Getenv(auth.PassphraseEnv)
+		}
+
+		method, err := ssh.NewPublicKeysFromFile(defaultValue(auth.Username, defaultSSHUser), keyPath, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the ssh key %q of repository %q: %w", keyPath, r.Name, err)
+		}
+
+		return method, nil
+	case types.TokenAuthType:
+		token := os.Getenv(auth.TokenEnv)
+		if auth.TokenEnv == "" || token == "" {
+			return nil, fmt.Errorf(
+				"failed to read the token of repository %q: empty environment variable %q", r.Name, auth.TokenEnv,
+			)
+		}
+
+		return &http.BasicAuth{Username: defaultValue(auth.Username, defaultTokenUser), Password: token}, nil
+	case types.CredentialHelperAuthType:
+		return credentialHelperAuth(ctx, r)
+	default:
+		return nil, fmt.Errorf("unexpected repository %q auth type: %d", r.Name, auth.Type)
+	}
+}
+
+// credentialHelperAuth returns the HTTP basic authentication of the given code repository filled by
+// `git credential fill`, using the repository credential helper or the configured git credential helpers.
+// The terminal prompts are disabled.
+func credentialHelperAuth(ctx context.Context, r *types.Repository) (transport.AuthMethod, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("failed to use the credential helper of repository %q: unexpected url", r.Name)
+	}
+
+	args := []string{"credential", "fill"}
+	if r.Auth.Helper != "" {
+		args = append([]string{"-c", "credential.helper=", "-c", "credential.helper=" + r.Auth.Helper}, args...)
+	}
+
+	input := fmt.Sprintf("protocol=%s\nhost=%s\npath=%s\n", u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/"))
+	if r.Auth.Username != "" {
+		input += fmt.Sprintf("username=%s\n", r.Auth.Username)
+	}
+
+	var stdout bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Stdin = strings.NewReader(input + "\n")
+	cmd.Stdout = &stdout
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run the credential helper of repository %q: %w", r.Name, err)
+	}
+
+	auth := &http.BasicAuth{}
+
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+
+		switch key {
+		case "username":
+			auth.Username = value
+		case "password":
+			auth.Password = value
+		}
+	}
+
+	if auth.Password == "" {
+		return nil, fmt.Errorf("failed to run the credential helper of repository %q: %w", r.Name, errNoCredentials)
+	}
+
+	return auth, nil
+}
+
+// expandHome returns the given path with its leading `~` replaced by the home directory.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the home directory: %w", err)
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// defaultValue returns the given value, or the given default value when it is empty.
+func defaultValue(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+// redactedError represents an error whose message has the credentials of a code repository URL redacted,
+// the original error is still unwrapped for `errors.Is` and `errors.As`.
+type redactedError struct {
+	// err is the original error.
+	err error
+
+	// message is the redacted error message.
+	message string
+}
+
+// Error returns the redacted error message.
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the original error.
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactError returns the given error with the credentials of the given code repository URL redacted
+// from its message, the same error when the message has no credentials.
+func redactError(err error, rawURL string) error {
+	message := types.RedactSecrets(err.Error(), rawURL)
+	if message == err.Error() {
+		return err
+	}
+
+	return &redactedError{err: err, message: message}
+}
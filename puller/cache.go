@@ -0,0 +1,149 @@
+package puller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// ErrNotAvailableOffline is the error returned in offline mode when a code repository is not cached.
+var ErrNotAvailableOffline = errors.New("repository is not available offline")
+
+// Cache represents the local cache of bare mirrors of code repositories.
+// Mirrors are content-addressed by their normalized URL, so the same URL always maps to the same mirror.
+// The code repositories are full clones of the mirrors, not git worktrees nor clones sharing their objects,
+// so that they stay usable without the cache: every pulled repository costs the disk size of its objects
+// on top of the mirror, only the network transfers are saved.
+type Cache struct {
+	// dir is the absolute path of the cache directory.
+	dir string
+
+	// locks are the mutexes of the mirrors, keyed by the mirror directory path.
+	locks sync.Map
+}
+
+// NewCache returns a pointer to a Cache struct stored at the given directory.
+func NewCache(dir string) (*Cache, error) {
+	if dir == "" {
+		return nil, errors.New("failed to create the cache: empty directory")
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the cache directory: %w", err)
+	}
+
+	return &Cache{dir: absDir}, nil
+}
+
+// Dir returns the absolute path of the cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// MirrorDir returns the absolute path of the bare mirror of the given code repository URL.
+func (c *Cache) MirrorDir(url string) string {
+	sum := sha256.Sum256([]byte(normalizeURL(url)))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".git")
+}
+
+// Mirrors returns the absolute paths of the bare mirrors of the cache, in lexical order.
+func (c *Cache) Mirrors() ([]string, error) {
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the cache directory: %w", err)
+	}
+
+	mirrors := []string{}
+
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasSuffix(entry.Name(), ".git") {
+			mirrors = append(mirrors, filepath.Join(c.dir, entry.Name()))
+		}
+	}
+
+	return mirrors, nil
+}
+
+// lock locks the mutex of the given mirror directory and returns its unlock function.
+func (c *Cache) lock(dir string) func() {
+	lock, _ := c.locks.LoadOrStore(dir, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+
+	mu.Lock()
+
+	return mu.Unlock
+}
+
+// mirror creates or updates the bare mirror of the given code repository URL, authenticated with the given
+// authentication method, and returns its directory path.
+// In offline mode the mirror is never fetched and it must already exist.
+func (c *Cache) mirror(
+	ctx context.Context, url string, offline bool, auth transport.AuthMethod, progress *progressReporter,
+) (string, error) {
+	dir := c.MirrorDir(url)
+
+	unlock := c.lock(dir)
+	defer unlock()
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil && !errors.Is(err, git.ErrRepositoryNotExists) {
+		return "", fmt.Errorf("failed to open a git mirror: %w", err)
+	}
+
+	if err != nil {
+		if offline {
+			return "", fmt.Errorf("failed to find a git mirror of %q: %w", url, ErrNotAvailableOffline)
+		}
+
+		if _, err := git.PlainCloneContext(ctx, dir, true, &git.CloneOptions{
+			URL:      url,
+			Auth:     auth,
+			Mirror:   true,
+			Progress: progress.writer(),
+		}); err != nil {
+			return "", fmt.Errorf("failed to clone a git mirror: %w", err)
+		}
+
+		return dir, nil
+	}
+
+	if offline {
+		return dir, nil
+	}
+
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		Auth:     auth,
+		Progress: progress.writer(),
+		Force:    true,
+		Prune:    true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return "", fmt.Errorf("failed to fetch a git mirror: %w", err)
+	}
+
+	return dir, nil
+}
+
+// normalizeURL returns the given code repository URL without the surrounding spaces,
+// the trailing slashes and the `.git` suffix.
+func normalizeURL(url string) string {
+	url = strings.TrimSpace(url)
+	url = strings.TrimRight(url, "/")
+
+	return strings.TrimSuffix(url, ".git")
+}
@@ -0,0 +1,149 @@
+package puller
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestCacheMirrorDir(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	expected := cache.MirrorDir("https://github.com/graphql/graphql-js")
+
+	for _, url := range []string{
+		"https://github.com/graphql/graphql-js.git",
+		"https://github.com/graphql/graphql-js/",
+		" https://github.com/graphql/graphql-js ",
+	} {
+		assert.Equal(t, expected, cache.MirrorDir(url))
+	}
+
+	assert.NotEqual(t, expected, cache.MirrorDir("https://github.com/graphql-go/graphql"))
+}
+
+func TestPullerPullCache(t *testing.T) {
+	remote := newTestRemote(t)
+	cache := testCache(t)
+
+	repo := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0"}
+
+	if _, err := New().Pull(&PullParams{Workspace: testWorkspace(t), Implementation: repo, Cache: cache}); err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	if _, err := os.Stat(cache.MirrorDir(remote.dir)); err != nil {
+		t.Fatalf("expected: mirror to exist, got: %v", err)
+	}
+
+	if err := os.RemoveAll(remote.dir); err != nil {
+		t.Fatalf("failed to remove remote: %v", err)
+	}
+
+	tests := []struct {
+		subTestName   string
+		referenceName string
+		cache         *Cache
+		expectedErr   error
+	}{
+		{
+			subTestName:   "Handles offline pull from the cache",
+			referenceName: "v0.2.0",
+			cache:         cache,
+		},
+		{
+			subTestName:   "Handles offline pull of a missing reference",
+			referenceName: "v9.9.9",
+			cache:         cache,
+			expectedErr:   &ReferenceNotFoundError{},
+		},
+		{
+			subTestName:   "Handles offline pull of a missing mirror",
+			referenceName: "v0.1.0",
+			cache:         testCache(t),
+			expectedErr:   ErrNotAvailableOffline,
+		},
+		{
+			subTestName:   "Handles offline pull without cache",
+			referenceName: "v0.1.0",
+			cache:         nil,
+			expectedErr:   ErrNotAvailableOffline,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			ws := testWorkspace(t)
+			repo := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: tt.referenceName}
+
+			_, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo, Cache: tt.cache, Offline: true})
+
+			switch expectedErr := tt.expectedErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("expected: nil, got: %v", err)
+				}
+
+				if head := testHead(t, ws.RepoDir(repo)); head != remote.second {
+					t.Fatalf("expected: %s, got: %s", remote.second, head)
+				}
+			case *ReferenceNotFoundError:
+				if !errors.As(err, &expectedErr) {
+					t.Fatalf("expected: %T, got: %v", expectedErr, err)
+				}
+			default:
+				if !errors.Is(err, expectedErr) {
+					t.Fatalf("expected: %v, got: %v", expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPullerPullCacheIndependentClone(t *testing.T) {
+	remote := newTestRemote(t)
+	cache := testCache(t)
+	ws := testWorkspace(t)
+
+	repo := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0"}
+
+	if _, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo, Cache: cache}); err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	if err := os.RemoveAll(cache.Dir()); err != nil {
+		t.Fatalf("failed to remove cache: %v", err)
+	}
+
+	repo.ReferenceName = "v0.2.0"
+
+	result, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo, Offline: true})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, ReuseAction, result.Repository(repo.Name).Action)
+
+	if head := testHead(t, ws.RepoDir(repo)); head != remote.second {
+		t.Fatalf("expected: %s, got: %s", remote.second, head)
+	}
+}
+
+// testCache returns a cache stored at a temporary directory.
+func testCache(t *testing.T) *Cache {
+	t.Helper()
+
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+
+	return cache
+}
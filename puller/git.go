@@ -0,0 +1,458 @@
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// ErrDirtyWorktree is the error returned when reusing a code repository whose worktree has local changes
+// that checking out its reference name would discard.
+var ErrDirtyWorktree = errors.New("worktree has local changes")
+
+// gitSource represents the source that pulls a code repository from a git remote.
+type gitSource struct {
+}
+
+// gitRequest represents the request of pulling a code repository from a git remote.
+type gitRequest struct {
+	*SourceRequest
+
+	// url is the URL the code repository is cloned and fetched from, the cached mirror when the cache is enabled.
+	url string
+
+	// auth is the authentication method of the URL, nil for the cached mirrors and the public code repositories.
+	auth transport.AuthMethod
+}
+
+// Pull clones the requested repository, or updates it using the existing repository policy
+// when it is already cloned.
+// Returns the action that was performed.
+func (s *gitSource) Pull(ctx context.Context, sourceReq *SourceRequest) (PullAction, error) {
+	req := &gitRequest{SourceRequest: sourceReq, url: sourceReq.Repository.URL}
+
+	if !req.Params.Offline {
+		auth, err := resolveAuth(ctx, req.Repository)
+		if err != nil {
+			return 0, err
+		}
+
+		req.auth = auth
+	}
+
+	if req.Params.Cache != nil {
+		mirrorDir, err := req.Params.Cache.mirror(ctx, req.Repository.URL, req.Params.Offline, req.auth, req.progress)
+		if err != nil {
+			return 0, err
+		}
+
+		req.url = mirrorDir
+		req.auth = nil
+	}
+
+	repo, err := git.PlainOpen(req.Dir)
+	if err == nil {
+		return s.updateRepo(ctx, repo, req)
+	}
+
+	if !errors.Is(err, git.ErrRepositoryNotExists) {
+		return CloneAction, fmt.Errorf("failed to open a git repository: %w", err)
+	}
+
+	if req.Params.Offline && req.Params.Cache == nil {
+		return CloneAction, fmt.Errorf("failed to clone a git repository: %w", ErrNotAvailableOffline)
+	}
+
+	return CloneAction, s.cloneRepo(ctx, req)
+}
+
+// cloneRepo clones the requested repository and checks out its reference name.
+// What the clone created is removed when the context is done before the clone completes.
+func (s *gitSource) cloneRepo(ctx context.Context, req *gitRequest) error {
+	if err := os.MkdirAll(filepath.Dir(req.Dir), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create a directory: %w", err)
+	}
+
+	target, err := newCloneTarget(req.Dir)
+	if err != nil {
+		return err
+	}
+
+	err = s.cloneAndCheckout(ctx, req, target)
+	if err != nil && ctx.Err() != nil {
+		if rmErr := target.cleanup(); rmErr != nil {
+			return fmt.Errorf("failed to remove a partially cloned repository: %w", rmErr)
+		}
+	}
+
+	return err
+}
+
+// cloneTarget represents the state of a directory before cloning into it, so that a failed clone only removes
+// what it created.
+type cloneTarget struct {
+	// dir is the directory path.
+	dir string
+
+	// existed represents whether or not the directory existed before the clone.
+	existed bool
+}
+
+// newCloneTarget returns a pointer to a cloneTarget struct of the current state of the given directory.
+// Non-empty directories are rejected, the same as `git clone` does, since the checkout would overwrite their content.
+func newCloneTarget(dir string) (*cloneTarget, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return &cloneTarget{dir: dir}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the repository directory: %w", err)
+	}
+
+	if len(entries) > 0 {
+		return nil, fmt.Errorf("failed to clone a git repository: directory %q is not empty", dir)
+	}
+
+	return &cloneTarget{dir: dir, existed: true}, nil
+}
+
+// cleanup removes what a clone created: the directory when it did not exist, its content otherwise.
+func (t *cloneTarget) cleanup() error {
+	if !t.existed {
+		return os.RemoveAll(t.dir)
+	}
+
+	entries, err := os.ReadDir(t.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(t.dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloneAndCheckout clones the requested repository and checks out its reference name.
+// Repositories with include paths are sparsely cloned, with a fallback to a full clone
+// when the remote or the reference name does not support it, what the sparse clone created is then removed.
+func (s *gitSource) cloneAndCheckout(ctx context.Context, req *gitRequest, target *cloneTarget) error {
+	if len(sparseDirs(req.Repository.IncludePaths)) > 0 {
+		err := s.sparseCloneAndCheckout(ctx, req)
+		if !errors.Is(err, errSparseNotSupported) || ctx.Err() != nil {
+			return err
+		}
+
+		if err := target.cleanup(); err != nil {
+			return fmt.Errorf("failed to remove a partially cloned repository: %w", err)
+		}
+	}
+
+	repo, err := git.PlainCloneContext(ctx, req.Dir, false, &git.CloneOptions{
+		URL:      req.url,
+		Auth:     req.auth,
+		Progress: req.progress.writer(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clone a git repository: %w", err)
+	}
+
+	if size, err := dirSize(filepath.Join(req.Dir, git.GitDirName)); err == nil {
+		req.progress.emit(ProgressEvent{Phase: ReceivingPhase, Percentage: 100, Bytes: size})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.checkoutReference(repo, req.Repository, req.progress)
+}
+
+// updateRepo updates the requested already cloned repository using the existing repository policy.
+// In offline mode without cache the repository is not fetched, shallow repositories are recloned instead of fetched.
+// Returns the action that was performed.
+func (s *gitSource) updateRepo(ctx context.Context, repo *git.Repository, req *gitRequest) (PullAction, error) {
+	policy := req.Params.ExistingRepoPolicy
+
+	if req.Params.Offline && req.Params.Cache == nil && policy != ReusePolicy {
+		policy = ReusePolicy
+	}
+
+	switch policy {
+	case ReusePolicy:
+		return ReuseAction, s.reuseRepo(repo, req)
+	case ReclonePolicy:
+		if err := os.RemoveAll(req.Dir); err != nil {
+			return RecloneAction, fmt.Errorf("failed to remove a git repository: %w", err)
+		}
+
+		return RecloneAction, s.cloneRepo(ctx, req)
+	case FetchAndResetPolicy, 0:
+		if isShallow(repo) {
+			if err := os.RemoveAll(req.Dir); err != nil {
+				return RecloneAction, fmt.Errorf("failed to remove a git repository: %w", err)
+			}
+
+			return RecloneAction, s.cloneRepo(ctx, req)
+		}
+
+		if err := s.fetch(ctx, repo, req); err != nil {
+			return FetchAction, err
+		}
+
+		if req.Repository.ReferenceName == "" {
+			return FetchAction, s.resetToRemoteBranch(repo, req.progress)
+		}
+
+		return FetchAction, s.checkoutReference(repo, req.Repository, req.progress)
+	default:
+		return 0, fmt.Errorf("unexpected existing repository policy: %d", policy)
+	}
+}
+
+// fetch fetches the branches and the tags of the requested repository, moved tags are overwritten.
+func (s *gitSource) fetch(ctx context.Context, repo *git.Repository, req *gitRequest) error {
+	err := repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: git.DefaultRemoteName,
+		RemoteURL:  req.url,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", git.DefaultRemoteName)),
+			config.RefSpec("+refs/tags/*:refs/tags/*"),
+		},
+		Auth:     req.auth,
+		Progress: req.progress.writer(),
+		Force:    true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to fetch a git repository: %w", err)
+	}
+
+	req.progress.emit(ProgressEvent{Phase: ReceivingPhase, Percentage: 100})
+
+	return nil
+}
+
+// resetToRemoteBranch resets the checked out branch to its remote branch.
+// Detached worktrees are kept as is.
+func (s *gitSource) resetToRemoteBranch(repo *git.Repository, progress *progressReporter) error {
+	head, err := repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return fmt.Errorf("failed to get the head reference: %w", err)
+	}
+
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return nil
+	}
+
+	remoteName := plumbing.NewRemoteReferenceName(git.DefaultRemoteName, head.Target().Short())
+
+	remote, err := repo.Reference(remoteName, true)
+	if err != nil {
+		return fmt.Errorf("failed to get the remote branch %q: %w", remoteName.Short(), err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get the worktree: %w", err)
+	}
+
+	progress.emit(ProgressEvent{Phase: CheckoutPhase})
+
+	if err := w.Reset(&git.ResetOptions{Commit: remote.Hash(), Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("failed to reset to the remote branch %q: %w", remoteName.Short(), err)
+	}
+
+	progress.emit(ProgressEvent{Phase: CheckoutPhase, Percentage: 100})
+
+	return nil
+}
+
+// reuseRepo checks out the reference name of the requested already cloned repository without fetching.
+// Local changes are never discarded: the worktree is kept as is when it already has the reference name
+// checked out, otherwise ErrDirtyWorktree is returned when it has local changes or untracked files,
+// which the go-git checkout would remove.
+func (s *gitSource) reuseRepo(repo *git.Repository, req *gitRequest) error {
+	if req.Repository.ReferenceName == "" {
+		return nil
+	}
+
+	hash, err := referenceHash(repo, req.Repository)
+	if err != nil {
+		return err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get the head reference: %w", err)
+	}
+
+	if head.Hash() == hash {
+		return nil
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get the worktree: %w", err)
+	}
+
+	status, err := w.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get the worktree status: %w", err)
+	}
+
+	if !status.IsClean() {
+		return fmt.Errorf("failed to checkout reference %q: %w", req.Repository.ReferenceName, ErrDirtyWorktree)
+	}
+
+	return s.checkout(repo, hash, req.Repository, false, req.progress)
+}
+
+// checkoutReference checks out the repository reference name in a detached worktree.
+// The default branch is kept when the reference name is empty.
+func (s *gitSource) checkoutReference(repo *git.Repository, r *types.Repository, progress *progressReporter) error {
+	if r.ReferenceName == "" {
+		return nil
+	}
+
+	hash, err := referenceHash(repo, r)
+	if err != nil {
+		return err
+	}
+
+	return s.checkout(repo, hash, r, true, progress)
+}
+
+// referenceHash returns the commit hash of the repository reference name.
+func referenceHash(repo *git.Repository, r *types.Repository) (plumbing.Hash, error) {
+	hash, err := resolveReference(repo, r.ReferenceName)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return plumbing.ZeroHash, &ReferenceNotFoundError{Repository: r.Name, ReferenceName: r.ReferenceName}
+		}
+
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve reference: %w", err)
+	}
+
+	return hash, nil
+}
+
+// checkout checks out the given commit hash in a detached worktree, only the include paths directories
+// of the repository are checked out when it has include paths.
+// Forcing the checkout discards the local changes and the untracked files.
+func (s *gitSource) checkout(
+	repo *git.Repository, hash plumbing.Hash, r *types.Repository, force bool, progress *progressReporter,
+) error {
+	w, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get the worktree: %w", err)
+	}
+
+	progress.emit(ProgressEvent{Phase: CheckoutPhase})
+
+	if err := w.Checkout(&git.CheckoutOptions{
+		Hash:                      hash,
+		Force:                     force,
+		SparseCheckoutDirectories: sparseDirs(r.IncludePaths),
+	}); err != nil {
+		return fmt.Errorf("failed to checkout reference %q: %w", r.ReferenceName, err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get the head reference: %w", err)
+	}
+
+	if head.Hash() != hash {
+		return fmt.Errorf("failed to verify reference %q: expected %s, got %s", r.ReferenceName, hash, head.Hash())
+	}
+
+	progress.emit(ProgressEvent{Phase: CheckoutPhase, Percentage: 100})
+
+	return nil
+}
+
+// resolveReference returns the commit hash of the given reference name.
+// The reference name is resolved as a tag, a remote branch, a local branch and finally as a commit hash.
+func resolveReference(repo *git.Repository, referenceName string) (plumbing.Hash, error) {
+	candidates := []plumbing.ReferenceName{
+		plumbing.NewTagReferenceName(referenceName),
+		plumbing.NewRemoteReferenceName(git.DefaultRemoteName, referenceName),
+		plumbing.NewBranchReferenceName(referenceName),
+	}
+
+	for _, c := range candidates {
+		ref, err := repo.Reference(c, true)
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		return commitHash(repo, ref.Hash())
+	}
+
+	if !isHexHash(referenceName) {
+		return plumbing.ZeroHash, plumbing.ErrReferenceNotFound
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(referenceName))
+	if err != nil {
+		return plumbing.ZeroHash, plumbing.ErrReferenceNotFound
+	}
+
+	return *hash, nil
+}
+
+// commitHash returns the commit hash the given hash points to, annotated tags are peeled.
+func commitHash(repo *git.Repository, hash plumbing.Hash) (plumbing.Hash, error) {
+	tag, err := repo.TagObject(hash)
+	if err == nil {
+		commit, err := tag.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		return commit.Hash, nil
+	}
+
+	if !errors.Is(err, plumbing.ErrObjectNotFound) {
+		return plumbing.ZeroHash, err
+	}
+
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return commit.Hash, nil
+}
+
+// isHexHash returns whether the given value looks like a full or abbreviated commit hash.
+func isHexHash(value string) bool {
+	if len(value) < 4 || len(value) > 40 {
+		return false
+	}
+
+	for _, c := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+
+	return true
+}
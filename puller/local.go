@@ -0,0 +1,27 @@
+package puller
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// localSource represents the source of an already existing local directory,
+// the directory is used in place, eg. for developing a graphql implementation.
+type localSource struct {
+}
+
+// Pull verifies that the requested directory exists, the directory is never modified.
+// Returns the reuse action.
+func (s *localSource) Pull(_ context.Context, req *SourceRequest) (PullAction, error) {
+	info, err := os.Stat(req.Dir)
+	if err != nil {
+		return ReuseAction, fmt.Errorf("failed to find the local directory: %w", err)
+	}
+
+	if !info.IsDir() {
+		return ReuseAction, fmt.Errorf("failed to find the local directory: %q is not a directory", req.Dir)
+	}
+
+	return ReuseAction, nil
+}
@@ -0,0 +1,187 @@
+package puller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// LockfileName is the default lockfile name, stored at the workspace root.
+const LockfileName = "repos.lock"
+
+// lockfileVersion is the current lockfile format version.
+const lockfileVersion = 1
+
+// LockMode is the mode of using the lockfile during a pull.
+type LockMode uint
+
+const (
+	// LockedMode checks out exactly the locked commits, the pull fails when a repository is not locked.
+	LockedMode LockMode = iota + 1
+
+	// UpdateLockMode pulls the repositories reference names and refreshes the lockfile with the resolved commits.
+	UpdateLockMode
+)
+
+// Lockfile represents the lockfile of the pulled code repositories, used for reproducible pulls.
+type Lockfile struct {
+	// Version is the lockfile format version.
+	Version int `json:"version"`
+
+	// Repositories are the locked code repositories, sorted by name.
+	Repositories []LockedRepository `json:"repositories"`
+}
+
+// LockedRepository represents a locked code repository.
+type LockedRepository struct {
+	// Name is the code repository name.
+	Name string `json:"name"`
+
+	// URL is the code repository source URL, its credentials are redacted.
+	URL string `json:"url"`
+
+	// Dir is the code repository directory override, empty for the default directory of the workspace.
+	Dir string `json:"dir,omitempty"`
+
+	// ReferenceName is the reference name that was resolved, eg. a tag.
+	ReferenceName string `json:"referenceName"`
+
+	// Commit is the resolved commit hash, empty for sources without commits.
+	Commit string `json:"commit"`
+}
+
+// ReadLockfile reads the lockfile at the given path.
+func ReadLockfile(path string) (*Lockfile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the lockfile: %w", err)
+	}
+
+	lockfile := &Lockfile{}
+	if err := json.Unmarshal(content, lockfile); err != nil {
+		return nil, fmt.Errorf("failed to decode the lockfile: %w", err)
+	}
+
+	if lockfile.Version != lockfileVersion {
+		return nil, fmt.Errorf("failed to decode the lockfile: unexpected version: %d", lockfile.Version)
+	}
+
+	return lockfile, nil
+}
+
+// Write writes the lockfile at the given path, the repositories are sorted by name.
+func (l *Lockfile) Write(path string) error {
+	l.Version = lockfileVersion
+
+	sort.Slice(l.Repositories, func(i, j int) bool {
+		return l.Repositories[i].Name < l.Repositories[j].Name
+	})
+
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the lockfile: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write the lockfile: %w", err)
+	}
+
+	return nil
+}
+
+// Repository returns the locked code repository with the given name, nil when it is not locked.
+func (l *Lockfile) Repository(name string) *LockedRepository {
+	for i := range l.Repositories {
+		if l.Repositories[i].Name == name {
+			return &l.Repositories[i]
+		}
+	}
+
+	return nil
+}
+
+// lock returns copies of the given repositories pinned to their locked commits.
+func (l *Lockfile) lock(repos []*types.Repository) ([]*types.Repository, error) {
+	locked := []*types.Repository{}
+
+	for _, r := range repos {
+		entry := l.Repository(r.Name)
+		if entry == nil {
+			return nil, fmt.Errorf("failed to lock repository %q: repository not found in the lockfile", r.Name)
+		}
+
+		url := types.RedactURL(r.URL)
+
+		if normalizeURL(entry.URL) != normalizeURL(url) {
+			return nil, fmt.Errorf(
+				"failed to lock repository %q: locked url %q does not match %q", r.Name, entry.URL, url,
+			)
+		}
+
+		repo := *r
+
+		if entry.Commit != "" {
+			repo.ReferenceName = entry.Commit
+			repo.Commit = entry.Commit
+		}
+
+		locked = append(locked, &repo)
+	}
+
+	return locked, nil
+}
+
+// update updates the lockfile with the successfully pulled repositories of the given result,
+// the other locked repositories are kept as is.
+func (l *Lockfile) update(repos []*types.Repository, result *PullResult) {
+	for i, r := range repos {
+		repoResult := result.Repositories[i]
+		if repoResult.Err != nil {
+			continue
+		}
+
+		entry := LockedRepository{
+			Name:          r.Name,
+			URL:           types.RedactURL(r.URL),
+			Dir:           r.Dir,
+			ReferenceName: repoResult.ReferenceName,
+			Commit:        repoResult.Commit,
+		}
+
+		if existing := l.Repository(r.Name); existing != nil {
+			*existing = entry
+			continue
+		}
+
+		l.Repositories = append(l.Repositories, entry)
+	}
+}
+
+// lockfilePath returns the given lockfile path resolved from the workspace root,
+// defaults to the `repos.lock` file of the workspace root.
+func lockfilePath(ws *Workspace, path string) string {
+	if path == "" {
+		return filepath.Join(ws.Root(), LockfileName)
+	}
+
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(ws.Root(), path)
+}
+
+// readOrNewLockfile reads the lockfile at the given path, an empty lockfile is returned when it does not exist.
+func readOrNewLockfile(path string) (*Lockfile, error) {
+	lockfile, err := ReadLockfile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Lockfile{Version: lockfileVersion}, nil
+	}
+
+	return lockfile, err
+}
@@ -0,0 +1,127 @@
+package puller
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestPullerPullLockfile(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+
+	repo := &types.Repository{
+		Name:          testRepoName(0),
+		URL:           remote.dir,
+		ReferenceName: "master",
+	}
+
+	if _, err := New().Pull(&PullParams{
+		Workspace:      ws,
+		Implementation: repo,
+		LockMode:       UpdateLockMode,
+	}); err != nil {
+		t.Fatalf("failed to pull: %v", err)
+	}
+
+	lockfile, err := ReadLockfile(filepath.Join(ws.Root(), LockfileName))
+	if err != nil {
+		t.Fatalf("failed to read lockfile: %v", err)
+	}
+
+	expected := []LockedRepository{
+		{Name: repo.Name, URL: remote.dir, ReferenceName: "master", Commit: remote.second.String()},
+	}
+	assert.Equal(t, expected, lockfile.Repositories)
+
+	remote.commitAndTag(t, "v0.3.0")
+
+	lockedWs := testWorkspace(t)
+
+	tests := []struct {
+		subTestName  string
+		lockfilePath string
+		workspace    *Workspace
+	}{
+		{
+			subTestName: "Handles default lockfile path",
+			workspace:   ws,
+		},
+		{
+			subTestName:  "Handles relative lockfile path",
+			lockfilePath: filepath.Join("..", filepath.Base(ws.Root()), LockfileName),
+			workspace:    lockedWs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			result, err := New().Pull(&PullParams{
+				Workspace:          tt.workspace,
+				Implementation:     repo,
+				LockMode:           LockedMode,
+				LockfilePath:       tt.lockfilePath,
+				ExistingRepoPolicy: FetchAndResetPolicy,
+			})
+			if err != nil {
+				t.Fatalf("failed to pull: %v", err)
+			}
+
+			assert.Equal(t, remote.second, testHead(t, tt.workspace.RepoDir(repo)))
+			assert.Equal(t, "master", result.Repository(repo.Name).ReferenceName)
+			assert.Equal(t, "master", repo.ReferenceName)
+		})
+	}
+}
+
+func TestPullerPullLockfileErrors(t *testing.T) {
+	remote := newTestRemote(t)
+
+	tests := []struct {
+		subTestName  string
+		repositories []LockedRepository
+	}{
+		{
+			subTestName: "Handles repository not found in the lockfile",
+			repositories: []LockedRepository{
+				{Name: testRepoName(1), URL: remote.dir, Commit: remote.first.String()},
+			},
+		},
+		{
+			subTestName: "Handles mismatching locked url",
+			repositories: []LockedRepository{
+				{Name: testRepoName(0), URL: "https://github.com/graphql/graphql-js", Commit: remote.first.String()},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			ws := testWorkspace(t)
+
+			lockfile := &Lockfile{Repositories: tt.repositories}
+			if err := lockfile.Write(filepath.Join(ws.Root(), LockfileName)); err != nil {
+				t.Fatalf("failed to write lockfile: %v", err)
+			}
+
+			_, err := New().Pull(&PullParams{
+				Workspace:      ws,
+				Implementation: &types.Repository{Name: testRepoName(0), URL: remote.dir},
+				LockMode:       LockedMode,
+			})
+			assert.NotNil(t, err)
+		})
+	}
+
+	t.Run("Handles missing lockfile", func(t *testing.T) {
+		_, err := New().Pull(&PullParams{
+			Workspace:      testWorkspace(t),
+			Implementation: &types.Repository{Name: testRepoName(0), URL: remote.dir},
+			LockMode:       LockedMode,
+		})
+		assert.NotNil(t, err)
+	})
+}
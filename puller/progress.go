@@ -0,0 +1,215 @@
+package puller
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/sideband"
+)
+
+// ProgressPhase is the phase of pulling a code repository.
+type ProgressPhase uint
+
+const (
+	// CountingPhase is the phase where the remote counts the objects to send.
+	CountingPhase ProgressPhase = iota + 1
+
+	// CompressingPhase is the phase where the remote compresses the objects to send.
+	CompressingPhase
+
+	// ReceivingPhase is the phase where the objects are received from the remote.
+	ReceivingPhase
+
+	// ResolvingPhase is the phase where the received deltas are resolved.
+	ResolvingPhase
+
+	// CheckoutPhase is the phase where the reference name is checked out into the worktree.
+	CheckoutPhase
+)
+
+// String returns the string representation of the progress phase.
+func (p ProgressPhase) String() string {
+	switch p {
+	case CountingPhase:
+		return "counting"
+	case CompressingPhase:
+		return "compressing"
+	case ReceivingPhase:
+		return "receiving"
+	case ResolvingPhase:
+		return "resolving"
+	case CheckoutPhase:
+		return "checkout"
+	default:
+		return "unknown"
+	}
+}
+
+// ProgressEvent represents a progress update of pulling a code repository.
+type ProgressEvent struct {
+	// Repository is the code repository name.
+	Repository string
+
+	// Phase is the current phase of the pull.
+	Phase ProgressPhase
+
+	// Percentage is the completion percentage of the phase, from 0 to 100.
+	Percentage int
+
+	// Current is the number of objects processed in the phase, zero when unknown.
+	Current int64
+
+	// Total is the total number of objects of the phase, zero when unknown.
+	Total int64
+
+	// Bytes is the number of bytes processed in the phase, zero when unknown.
+	Bytes int64
+}
+
+// String returns the progress event as a structured log line.
+func (e ProgressEvent) String() string {
+	return fmt.Sprintf(
+		"repository=%s phase=%s percentage=%d current=%d total=%d bytes=%d",
+		e.Repository, e.Phase, e.Percentage, e.Current, e.Total, e.Bytes,
+	)
+}
+
+// progressPhases are the progress phases keyed by the git progress message titles.
+var progressPhases = map[string]ProgressPhase{
+	"Enumerating objects": CountingPhase,
+	"Counting objects":    CountingPhase,
+	"Compressing objects": CompressingPhase,
+	"Receiving objects":   ReceivingPhase,
+	"Resolving deltas":    ResolvingPhase,
+}
+
+// progressLineRegexp matches a git progress message, eg. `Receiving objects:  50% (1/2), 1.00 KiB`.
+var progressLineRegexp = regexp.MustCompile(
+	`^(?:remote: )?([A-Za-z ]+):\s+(\d+)% \((\d+)/(\d+)\)(?:, ([\d.]+) (B|KiB|MiB|GiB))?`,
+)
+
+// progressTotalRegexp matches the git message sent by the remote before the objects, eg. `Total 9 (delta 1)`.
+var progressTotalRegexp = regexp.MustCompile(`^(?:remote: )?Total (\d+)`)
+
+// byteUnits are the multipliers of the git progress byte units.
+var byteUnits = map[string]float64{
+	"B":   1,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+}
+
+// progressReporter reports the progress events of a single code repository.
+type progressReporter struct {
+	// repository is the code repository name.
+	repository string
+
+	// callback is the function called on every progress event, nil disables the reporting.
+	callback func(event ProgressEvent)
+}
+
+// newProgressCallback returns the given callback guarded by a mutex,
+// so that the callback is never called concurrently by the pull workers.
+func newProgressCallback(callback func(event ProgressEvent)) func(event ProgressEvent) {
+	if callback == nil {
+		return nil
+	}
+
+	mu := sync.Mutex{}
+
+	return func(event ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		callback(event)
+	}
+}
+
+// emit reports the given progress event for the code repository.
+func (r *progressReporter) emit(event ProgressEvent) {
+	if r == nil || r.callback == nil {
+		return
+	}
+
+	event.Repository = r.repository
+	r.callback(event)
+}
+
+// writer returns the sideband progress writer that parses the git progress messages,
+// returns nil when the reporting is disabled so that the remote does not send progress messages.
+func (r *progressReporter) writer() sideband.Progress {
+	if r == nil || r.callback == nil {
+		return nil
+	}
+
+	return &progressWriter{reporter: r}
+}
+
+// progressWriter represents the writer that turns git progress messages into progress events.
+type progressWriter struct {
+	// reporter is the progress reporter of the code repository.
+	reporter *progressReporter
+
+	// buf is the buffer of the incomplete progress message.
+	buf []byte
+}
+
+// Write parses the complete progress messages of the given bytes, messages are ended by a carriage return
+// or a new line.
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		idx := bytes.IndexAny(w.buf, "\r\n")
+		if idx < 0 {
+			break
+		}
+
+		w.parse(strings.TrimSpace(string(w.buf[:idx])))
+		w.buf = w.buf[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+// parse reports the progress event of the given progress message, unknown messages are ignored.
+func (w *progressWriter) parse(line string) {
+	if m := progressTotalRegexp.FindStringSubmatch(line); m != nil {
+		total, _ := strconv.ParseInt(m[1], 10, 64)
+		w.reporter.emit(ProgressEvent{Phase: ReceivingPhase, Total: total})
+
+		return
+	}
+
+	m := progressLineRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return
+	}
+
+	phase, ok := progressPhases[m[1]]
+	if !ok {
+		return
+	}
+
+	percentage, _ := strconv.Atoi(m[2])
+	current, _ := strconv.ParseInt(m[3], 10, 64)
+	total, _ := strconv.ParseInt(m[4], 10, 64)
+
+	event := ProgressEvent{
+		Phase:      phase,
+		Percentage: percentage,
+		Current:    current,
+		Total:      total,
+	}
+
+	if m[5] != "" {
+		value, _ := strconv.ParseFloat(m[5], 64)
+		event.Bytes = int64(value * byteUnits[m[6]])
+	}
+
+	w.reporter.emit(event)
+}
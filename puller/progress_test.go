@@ -0,0 +1,81 @@
+package puller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressWriterWrite(t *testing.T) {
+	tests := []struct {
+		subTestName    string
+		messages       []string
+		expectedEvents []ProgressEvent
+	}{
+		{
+			subTestName: "Handles remote counting messages split by carriage returns",
+			messages:    []string{"Counting objects:  50% (1/2)   \rCounting obj", "ects: 100% (2/2), done.\n"},
+			expectedEvents: []ProgressEvent{
+				{Repository: "test-repo", Phase: CountingPhase, Percentage: 50, Current: 1, Total: 2},
+				{Repository: "test-repo", Phase: CountingPhase, Percentage: 100, Current: 2, Total: 2},
+			},
+		},
+		{
+			subTestName: "Handles receiving messages with bytes",
+			messages:    []string{"Receiving objects:  10% (3/30), 1.50 KiB | 1.00 MiB/s\r"},
+			expectedEvents: []ProgressEvent{
+				{Repository: "test-repo", Phase: ReceivingPhase, Percentage: 10, Current: 3, Total: 30, Bytes: 1536},
+			},
+		},
+		{
+			subTestName: "Handles total message",
+			messages:    []string{"Total 9 (delta 1), reused 0 (delta 0), pack-reused 0\n"},
+			expectedEvents: []ProgressEvent{
+				{Repository: "test-repo", Phase: ReceivingPhase, Total: 9},
+			},
+		},
+		{
+			subTestName:    "Handles unknown and incomplete messages",
+			messages:       []string{"Enumerating objects: 9, done.\n", "Resolving deltas:  50% (1/2)"},
+			expectedEvents: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			var events []ProgressEvent
+
+			reporter := &progressReporter{
+				repository: "test-repo",
+				callback: func(event ProgressEvent) {
+					events = append(events, event)
+				},
+			}
+
+			w := reporter.writer()
+
+			for _, m := range tt.messages {
+				n, err := w.Write([]byte(m))
+
+				assert.Nil(t, err)
+				assert.Equal(t, len(m), n)
+			}
+
+			assert.Equal(t, tt.expectedEvents, events)
+		})
+	}
+}
+
+func TestProgressReporterWriter(t *testing.T) {
+	reporter := &progressReporter{repository: "test-repo"}
+
+	assert.Nil(t, reporter.writer())
+}
+
+func TestProgressEventString(t *testing.T) {
+	event := ProgressEvent{Repository: "test-repo", Phase: CheckoutPhase, Percentage: 100}
+
+	expected := "repository=test-repo phase=checkout percentage=100 current=0 total=0 bytes=0"
+
+	assert.Equal(t, expected, event.String())
+}
@@ -0,0 +1,349 @@
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// WorkspaceEntry represents a directory of the `repos` directory of the workspace.
+type WorkspaceEntry struct {
+	// Name is the directory name.
+	Name string
+
+	// Path is the absolute directory path.
+	Path string
+
+	// Size is the size in bytes of the directory.
+	Size int64
+
+	// IsGit represents whether or not the directory is a git repository.
+	IsGit bool
+}
+
+// List returns the entries of the `repos` directory of the workspace, in lexical order.
+// Returns no entries when the `repos` directory does not exist.
+func (w *Workspace) List() ([]WorkspaceEntry, error) {
+	entries, err := os.ReadDir(w.ReposDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return []WorkspaceEntry{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the repos directory: %w", err)
+	}
+
+	result := []WorkspaceEntry{}
+
+	for _, entry := range entries {
+		path := filepath.Join(w.ReposDir(), entry.Name())
+
+		size, err := dirSize(path)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = os.Stat(filepath.Join(path, git.GitDirName))
+
+		result = append(result, WorkspaceEntry{
+			Name:  entry.Name(),
+			Path:  path,
+			Size:  size,
+			IsGit: err == nil,
+		})
+	}
+
+	return result, nil
+}
+
+// PruneReason is the reason of pruning a path of the workspace.
+type PruneReason uint
+
+const (
+	// UnreferencedReason is the reason of the code repositories no longer referenced by the configuration
+	// or the lockfile.
+	UnreferencedReason PruneReason = iota + 1
+
+	// LeftoverReason is the reason of the temporary files and directories left by interrupted pulls.
+	LeftoverReason
+)
+
+// String returns the string representation of the prune reason.
+func (r PruneReason) String() string {
+	switch r {
+	case UnreferencedReason:
+		return "unreferenced"
+	case LeftoverReason:
+		return "leftover"
+	default:
+		return "unknown"
+	}
+}
+
+// PruneParams represents the parameters of the prune method.
+type PruneParams struct {
+	// Repositories are the code repositories referenced by the active configuration, which are kept.
+	Repositories []types.Repository
+
+	// Workspace is the pruned workspace, defaults to the current working directory.
+	Workspace *Workspace
+
+	// LockfilePath is the lockfile path whose code repositories are kept, relative paths are resolved from the
+	// workspace root, defaults to the `repos.lock` file of the workspace root. A missing lockfile is ignored.
+	LockfilePath string
+
+	// Cache is the optional cache whose bare mirrors are garbage collected.
+	Cache *Cache
+
+	// DryRun represents whether or not the prune only reports what would be removed, without removing anything.
+	DryRun bool
+}
+
+// PruneResult represents the result of the prune method.
+type PruneResult struct {
+	// DryRun represents whether or not nothing was removed.
+	DryRun bool
+
+	// Kept are the referenced code repositories of the workspace.
+	Kept []WorkspaceEntry
+
+	// Removed are the removed paths, or the paths that would be removed in dry-run mode.
+	Removed []PrunedPath
+
+	// Mirrors are the garbage collected bare mirrors of the cache.
+	Mirrors []PrunedMirror
+}
+
+// PrunedPath represents a path removed from the workspace.
+type PrunedPath struct {
+	// Path is the absolute path.
+	Path string
+
+	// Size is the size in bytes of the path.
+	Size int64
+
+	// Reason is the reason of the removal.
+	Reason PruneReason
+}
+
+// PrunedMirror represents a garbage collected bare mirror.
+type PrunedMirror struct {
+	// Path is the absolute path of the mirror.
+	Path string
+
+	// SizeBefore is the size in bytes of the mirror before the garbage collection.
+	SizeBefore int64
+
+	// SizeAfter is the size in bytes of the mirror after the garbage collection, the same as before in dry-run mode.
+	SizeAfter int64
+}
+
+// ReclaimedSize returns the size in bytes reclaimed by the prune, or that would be reclaimed in dry-run mode
+// where the garbage collection of the mirrors is not measured.
+func (r *PruneResult) ReclaimedSize() int64 {
+	var size int64
+
+	for _, p := range r.Removed {
+		size += p.Size
+	}
+
+	for _, m := range r.Mirrors {
+		size += m.SizeBefore - m.SizeAfter
+	}
+
+	return size
+}
+
+// Summary returns the summary of the prune, eg. `removed 2 paths, kept 3 repositories, reclaimed 1.5 MiB`.
+func (r *PruneResult) Summary() string {
+	verb := "removed"
+	if r.DryRun {
+		verb = "would remove"
+	}
+
+	return fmt.Sprintf(
+		"%s %d paths, kept %d repositories, garbage collected %d mirrors, reclaimed %s",
+		verb, len(r.Removed), len(r.Kept), len(r.Mirrors), formatSize(r.ReclaimedSize()),
+	)
+}
+
+// Prune removes the code repositories of the workspace `repos` directory that are no longer referenced by the
+// given repositories or the lockfile and the leftovers of interrupted pulls, then garbage collects the bare mirrors of the cache.
+// Nothing is removed in dry-run mode.
+func (p *Puller) Prune(ctx context.Context, params *PruneParams) (*PruneResult, error) {
+	ws, err := params.workspace()
+	if err != nil {
+		return nil, err
+	}
+
+	referenced, err := referencedDirs(ws, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ws.List()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{DryRun: params.DryRun, Kept: []WorkspaceEntry{}, Removed: []PrunedPath{}}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		switch {
+		case strings.HasPrefix(entry.Name, "."):
+			result.Removed = append(result.Removed, PrunedPath{Path: entry.Path, Size: entry.Size, Reason: LeftoverReason})
+		case !referenced[entry.Path]:
+			result.Removed = append(result.Removed, PrunedPath{Path: entry.Path, Size: entry.Size, Reason: UnreferencedReason})
+		default:
+			result.Kept = append(result.Kept, entry)
+		}
+	}
+
+	if !params.DryRun {
+		for _, removed := range result.Removed {
+			if err := os.RemoveAll(removed.Path); err != nil {
+				return result, fmt.Errorf("failed to remove %q: %w", removed.Path, err)
+			}
+		}
+	}
+
+	if params.Cache != nil {
+		mirrors, err := p.gcMirrors(ctx, params.Cache, params.DryRun)
+		result.Mirrors = mirrors
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// workspace returns the workspace parameter, or the workspace of the current working directory when it is not set.
+func (p *PruneParams) workspace() (*Workspace, error) {
+	if p.Workspace != nil {
+		return p.Workspace, nil
+	}
+
+	return NewWorkspace("")
+}
+
+// referencedDirs returns the directories of the code repositories referenced by the given repositories
+// and by the lockfile.
+func referencedDirs(ws *Workspace, params *PruneParams) (map[string]bool, error) {
+	dirs := map[string]bool{}
+
+	for i := range params.Repositories {
+		dirs[ws.RepoDir(&params.Repositories[i])] = true
+	}
+
+	lockfile, err := ReadLockfile(lockfilePath(ws, params.LockfilePath))
+	if errors.Is(err, os.ErrNotExist) {
+		return dirs, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range lockfile.Repositories {
+		dirs[ws.RepoDir(&types.Repository{Name: r.Name, Dir: r.Dir})] = true
+	}
+
+	return dirs, nil
+}
+
+// gcMirrors garbage collects the bare mirrors of the given cache: the unreachable loose objects are removed
+// and the objects are repacked. The mirrors are only measured in dry-run mode.
+func (p *Puller) gcMirrors(ctx context.Context, cache *Cache, dryRun bool) ([]PrunedMirror, error) {
+	dirs, err := cache.Mirrors()
+	if err != nil {
+		return nil, err
+	}
+
+	mirrors := []PrunedMirror{}
+
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return mirrors, err
+		}
+
+		mirror, err := gcMirror(cache, dir, dryRun)
+		if err != nil {
+			return mirrors, err
+		}
+
+		mirrors = append(mirrors, *mirror)
+	}
+
+	return mirrors, nil
+}
+
+// gcMirror garbage collects the given bare mirror of the given cache.
+func gcMirror(cache *Cache, dir string, dryRun bool) (*PrunedMirror, error) {
+	unlock := cache.lock(dir)
+	defer unlock()
+
+	sizeBefore, err := dirSize(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror := &PrunedMirror{Path: dir, SizeBefore: sizeBefore, SizeAfter: sizeBefore}
+
+	if dryRun {
+		return mirror, nil
+	}
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a git mirror: %w", err)
+	}
+
+	if err := repo.Prune(git.PruneOptions{Handler: repo.DeleteObject}); err != nil {
+		return nil, fmt.Errorf("failed to prune a git mirror: %w", err)
+	}
+
+	if err := repo.RepackObjects(&git.RepackConfig{}); err != nil {
+		return nil, fmt.Errorf("failed to repack a git mirror: %w", err)
+	}
+
+	sizeAfter, err := dirSize(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror.SizeAfter = sizeAfter
+
+	return mirror, nil
+}
+
+// formatSize returns the given size in bytes with a binary unit, eg. `1.5 MiB`.
+func formatSize(size int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+
+	value := float64(size)
+	unit := 0
+
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
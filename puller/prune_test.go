@@ -0,0 +1,185 @@
+package puller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestPullerPrune(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		subTestName := "Handles prune"
+		if dryRun {
+			subTestName = "Handles dry-run prune"
+		}
+
+		t.Run(subTestName, func(t *testing.T) {
+			remote := newTestRemote(t)
+			ws := testWorkspace(t)
+
+			repos := []types.Repository{
+				{Name: testRepoName(0), URL: remote.dir},
+				{Name: testRepoName(1), URL: remote.dir},
+				{Name: testRepoName(2), URL: remote.dir},
+			}
+
+			if _, err := New().Pull(&PullParams{Workspace: ws, Repositories: repos}); err != nil {
+				t.Fatalf("failed to pull: %v", err)
+			}
+
+			lockfile := &Lockfile{Repositories: []LockedRepository{{Name: testRepoName(1), URL: remote.dir}}}
+			if err := lockfile.Write(filepath.Join(ws.Root(), LockfileName)); err != nil {
+				t.Fatalf("failed to write lockfile: %v", err)
+			}
+
+			// The leftovers of an interrupted archive pull: its extraction directory and its downloaded archive.
+			leftover := filepath.Join(ws.ReposDir(), "."+testRepoName(3)+"-123")
+			leftoverArchive := filepath.Join(ws.ReposDir(), "."+testRepoName(3)+"-456.archive")
+
+			if err := os.MkdirAll(leftover, os.ModePerm); err != nil {
+				t.Fatalf("failed to create directory: %v", err)
+			}
+
+			if err := os.WriteFile(leftoverArchive, []byte("archive"), 0o644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			result, err := New().Prune(context.Background(), &PruneParams{
+				Repositories: repos[:1],
+				Workspace:    ws,
+				DryRun:       dryRun,
+			})
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			expectedRemoved := map[string]PruneReason{
+				leftover:              LeftoverReason,
+				leftoverArchive:       LeftoverReason,
+				ws.RepoDir(&repos[2]): UnreferencedReason,
+			}
+
+			actualRemoved := map[string]PruneReason{}
+			for _, removed := range result.Removed {
+				actualRemoved[removed.Path] = removed.Reason
+			}
+
+			assert.Equal(t, expectedRemoved, actualRemoved)
+			assert.Equal(t, 2, len(result.Kept))
+			assert.Equal(t, dryRun, result.DryRun)
+			assert.Greater(t, result.ReclaimedSize(), int64(0))
+
+			for path := range expectedRemoved {
+				_, err := os.Stat(path)
+				assert.Equal(t, dryRun, err == nil)
+			}
+
+			for _, path := range []string{ws.RepoDir(&repos[0]), ws.RepoDir(&repos[1])} {
+				if _, err := os.Stat(path); err != nil {
+					t.Fatalf("expected: %q to exist, got: %v", path, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPullerPruneLockedDirOverride(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+
+	repo := types.Repository{Name: testRepoName(0), URL: remote.dir, Dir: filepath.Join(reposDirName, "custom")}
+
+	if _, err := New().Pull(&PullParams{
+		Workspace:    ws,
+		Repositories: []types.Repository{repo},
+		LockMode:     UpdateLockMode,
+	}); err != nil {
+		t.Fatalf("failed to pull: %v", err)
+	}
+
+	result, err := New().Prune(context.Background(), &PruneParams{Workspace: ws})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Empty(t, result.Removed)
+	assert.Equal(t, 1, len(result.Kept))
+
+	if _, err := os.Stat(ws.RepoDir(&repo)); err != nil {
+		t.Fatalf("expected: %q to exist, got: %v", ws.RepoDir(&repo), err)
+	}
+}
+
+func TestPullerPruneCache(t *testing.T) {
+	remote := newTestRemote(t)
+	cache := testCache(t)
+	repo := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0"}
+
+	if _, err := New().Pull(&PullParams{Workspace: testWorkspace(t), Implementation: repo, Cache: cache}); err != nil {
+		t.Fatalf("failed to pull: %v", err)
+	}
+
+	result, err := New().Prune(context.Background(), &PruneParams{Workspace: testWorkspace(t), Cache: cache})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, 1, len(result.Mirrors))
+	assert.Equal(t, cache.MirrorDir(remote.dir), result.Mirrors[0].Path)
+	assert.Greater(t, result.Mirrors[0].SizeAfter, int64(0))
+
+	mirror, err := git.PlainOpen(cache.MirrorDir(remote.dir))
+	if err != nil {
+		t.Fatalf("failed to open mirror: %v", err)
+	}
+
+	hash, err := resolveReference(mirror, "v0.2.0")
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, remote.second, hash)
+}
+
+func TestPruneResultSummary(t *testing.T) {
+	result := &PruneResult{
+		DryRun:  true,
+		Kept:    []WorkspaceEntry{{Name: testRepoName(0)}},
+		Removed: []PrunedPath{{Size: 1024}, {Size: 512}},
+	}
+
+	assert.Equal(t, "would remove 2 paths, kept 1 repositories, garbage collected 0 mirrors, reclaimed 1.5 KiB", result.Summary())
+}
+
+func TestWorkspaceList(t *testing.T) {
+	ws := testWorkspace(t)
+
+	entries, err := ws.List()
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, []WorkspaceEntry{}, entries)
+
+	dir := filepath.Join(ws.ReposDir(), testRepoName(0))
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	entries, err = ws.List()
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, []WorkspaceEntry{{Name: testRepoName(0), Path: dir, Size: 6}}, entries)
+}
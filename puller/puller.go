@@ -1,25 +1,32 @@
 package puller
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"sync"
+	"time"
 
-	"github.com/go-git/go-git/v5"
 	"github.com/graphql-go/compatibility-base/types"
 )
 
 // reposDirName is the code repository root directory name.
 const reposDirName = "repos"
 
+// defaultConcurrency is the default maximum number of code repositories pulled at the same time.
+const defaultConcurrency = 4
+
 // Puller represents the puller component.
 type Puller struct {
+	// sources are the sources used for pulling the code repositories, keyed by their source type.
+	sources map[types.SourceType]Source
 }
 
 // New returns a pointer to a Puller struct.
 func New() *Puller {
-	return &Puller{}
+	return &Puller{
+		sources: defaultSources(),
+	}
 }
 
 // PullParams represents the parameters of the pull method.
@@ -29,8 +36,61 @@ type PullParams struct {
 
 	// Implementation is the code repository of the graphql implementation.
 	Implementation *types.Repository
+
+	// Repositories are additional code repositories to pull, eg. a matrix of graphql implementations.
+	Repositories []types.Repository
+
+	// ExistingRepoPolicy is the policy applied to the code repositories that are already cloned.
+	ExistingRepoPolicy ExistingRepoPolicy
+
+	// Concurrency is the maximum number of code repositories pulled at the same time, defaults to 4.
+	Concurrency int
+
+	// Workspace is the workspace where the code repositories are pulled, defaults to the current working directory.
+	Workspace *Workspace
+
+	// Timeout is the maximum duration of the whole pull, zero means no timeout.
+	Timeout time.Duration
+
+	// RepositoryTimeout is the maximum duration of pulling a single code repository, zero means no timeout.
+	RepositoryTimeout time.Duration
+
+	// ProgressCallback is the function called on every pull progress event, nil disables the progress reporting.
+	// The callback is never called concurrently.
+	ProgressCallback func(event ProgressEvent)
+
+	// Cache is the cache of bare mirrors the code repositories are cloned from, nil disables the cache.
+	// The clones copy the objects of the mirrors, so the cache saves network transfers but not disk space.
+	Cache *Cache
+
+	// Offline represents whether or not the pull runs without network access,
+	// code repositories are then pulled only from the cache or reused from the workspace.
+	Offline bool
+
+	// LockMode is the mode of using the lockfile, zero disables the lockfile.
+	LockMode LockMode
+
+	// LockfilePath is the lockfile path, relative paths are resolved from the workspace root,
+	// defaults to the `repos.lock` file of the workspace root.
+	LockfilePath string
 }
 
+// ExistingRepoPolicy is the policy applied to an already cloned code repository.
+type ExistingRepoPolicy uint
+
+const (
+	// FetchAndResetPolicy fetches the existing clone and resets it to the reference name, it is the default policy.
+	FetchAndResetPolicy ExistingRepoPolicy = iota + 1
+
+	// ReusePolicy reuses the existing clone as is, the reference name is resolved without fetching.
+	// Local changes and untracked files are kept, the pull fails with ErrDirtyWorktree when checking out
+	// another commit would discard them.
+	ReusePolicy
+
+	// ReclonePolicy removes the existing clone and clones the code repository again.
+	ReclonePolicy
+)
+
 // repositories returns the parameters as a repositories slice.
 func (p *PullParams) repositories() []*types.Repository {
 	repos := []*types.Repository{}
@@ -43,74 +103,300 @@ func (p *PullParams) repositories() []*types.Repository {
 		repos = append(repos, p.Implementation)
 	}
 
+	for i := range p.Repositories {
+		repos = append(repos, &p.Repositories[i])
+	}
+
 	return repos
 }
 
-// PullResult represents the result of the pull method.
-type PullResult struct {
+// concurrency returns the number of workers used for pulling the given number of repositories.
+func (p *PullParams) concurrency(reposLen int) int {
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	return min(concurrency, reposLen)
+}
+
+// workspace returns the workspace parameter or the default workspace.
+func (p *PullParams) workspace() (*Workspace, error) {
+	if p.Workspace != nil {
+		return p.Workspace, nil
+	}
+
+	return NewWorkspace("")
+}
+
+// validateRepositories returns an error when two repositories share the same name or directory,
+// since they would be pulled into the same directory.
+func validateRepositories(repos []*types.Repository, ws *Workspace) error {
+	names := map[string]bool{}
+	dirs := map[string]bool{}
+
+	for _, r := range repos {
+		if r.Name == "" {
+			return errors.New("failed to validate repositories: empty repository name")
+		}
+
+		if names[r.Name] {
+			return fmt.Errorf("failed to validate repositories: duplicated repository name %q", r.Name)
+		}
+
+		dir := ws.RepoDir(r)
+		if dirs[dir] {
+			return fmt.Errorf("failed to validate repositories: duplicated repository directory %q", dir)
+		}
+
+		names[r.Name] = true
+		dirs[dir] = true
+	}
+
+	return nil
+}
+
+// ReferenceNotFoundError represents the error returned when a code repository reference name does not exist.
+type ReferenceNotFoundError struct {
+	// Repository is the code repository name.
+	Repository string
+
+	// ReferenceName is the reference name that was not found.
+	ReferenceName string
+}
+
+// Error returns the string representation of the error.
+func (e *ReferenceNotFoundError) Error() string {
+	return fmt.Sprintf("reference %q not found in repository %q", e.ReferenceName, e.Repository)
+}
+
+// VerificationError represents the error returned when the pulled content of a code repository
+// does not match its expected value.
+type VerificationError struct {
+	// Repository is the code repository name.
+	Repository string
+
+	// Subject is the verified subject, eg. `checksum`, `commit` or `tree`.
+	Subject string
+
+	// Expected is the expected value.
+	Expected string
+
+	// Actual is the actual value.
+	Actual string
+}
+
+// Error returns the string representation of the error.
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf(
+		"%s mismatch in repository %q: expected %s, got %s", e.Subject, e.Repository, e.Expected, e.Actual,
+	)
 }
 
 // Pull pulls a set of code repositories and returns the result.
+// When some of the repositories fail, the result is returned along the joined errors.
 func (p *Puller) Pull(params *PullParams) (*PullResult, error) {
+	return p.PullContext(context.Background(), params)
+}
+
+// PullContext pulls a set of code repositories using the given context and returns the result.
+// Cancelling the context stops the pending pulls and removes the partially cloned directories.
+// When some of the repositories fail, the result is returned along the joined errors.
+func (p *Puller) PullContext(ctx context.Context, params *PullParams) (*PullResult, error) {
+	if params.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, params.Timeout)
+		defer cancel()
+	}
+
 	repos := params.repositories()
 
-	if err := p.createReposDir(); err != nil {
+	ws, err := params.workspace()
+	if err != nil {
 		return nil, err
 	}
 
-	if err := p.gitCloneRepos(repos); err != nil {
+	if err := validateRepositories(repos, ws); err != nil {
 		return nil, err
 	}
 
-	return &PullResult{}, nil
+	if err := ws.createReposDir(); err != nil {
+		return nil, err
+	}
+
+	switch params.LockMode {
+	case LockedMode:
+		return p.pullLocked(ctx, repos, ws, params)
+	case UpdateLockMode:
+		return p.pullAndUpdateLock(ctx, repos, ws, params)
+	}
+
+	result := p.pullRepos(ctx, repos, ws, params)
+
+	if err := result.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
 }
 
-// createReposDir creates the `repos` directory and returns whether it succeeded or not.
-func (p *Puller) createReposDir() error {
-	if _, err := os.Stat(reposDirName); err != nil {
-		if os.IsNotExist(err) {
-			if err := os.Mkdir(reposDirName, os.ModePerm); err != nil {
-				return fmt.Errorf("failed to create a directory: %w", err)
-			}
-		} else {
-			return fmt.Errorf("failed to check if directory exist: %w", err)
-		}
+// pullLocked pulls the given repositories checking out exactly their locked commits.
+func (p *Puller) pullLocked(
+	ctx context.Context, repos []*types.Repository, ws *Workspace, params *PullParams,
+) (*PullResult, error) {
+	lockfile, err := ReadLockfile(lockfilePath(ws, params.LockfilePath))
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	lockedRepos, err := lockfile.lock(repos)
+	if err != nil {
+		return nil, err
+	}
+
+	result := p.pullRepos(ctx, lockedRepos, ws, params)
+
+	for _, r := range result.Repositories {
+		r.ReferenceName = lockfile.Repository(r.Name).ReferenceName
+	}
+
+	if err := result.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
 }
 
-// gitCloneRepos clones the given repositories and returns whether or not it succeeded.
-func (p *Puller) gitCloneRepos(repos []*types.Repository) error {
-	for _, r := range repos {
-		name := filepath.Join(reposDirName, r.Name)
+// pullAndUpdateLock pulls the given repositories and refreshes the lockfile with the resolved commits.
+func (p *Puller) pullAndUpdateLock(
+	ctx context.Context, repos []*types.Repository, ws *Workspace, params *PullParams,
+) (*PullResult, error) {
+	path := lockfilePath(ws, params.LockfilePath)
 
-		if err := p.createRepoDir(r.Name); err != nil {
-			return err
-		}
+	lockfile, err := readOrNewLockfile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := p.pullRepos(ctx, repos, ws, params)
+
+	lockfile.update(repos, result)
+
+	if err := lockfile.Write(path); err != nil {
+		return result, errors.Join(result.Err(), err)
+	}
+
+	if err := result.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// pullRepos pulls the given repositories using a bounded pool of workers and returns the result.
+// Already pulled repositories are handled using the existing repository policy.
+func (p *Puller) pullRepos(
+	ctx context.Context, repos []*types.Repository, ws *Workspace, params *PullParams,
+) *PullResult {
+	results := make([]*RepositoryResult, len(repos))
+	progressCallback := newProgressCallback(params.ProgressCallback)
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for range params.concurrency(len(repos)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
 
-		if _, err := git.PlainClone(name, false, &git.CloneOptions{
-			URL:      r.URL,
-			Progress: os.Stdout,
-		}); err != nil {
-			if strings.Contains(err.Error(), "repository already exists") {
-				return nil
+			for idx := range jobs {
+				r := repos[idx]
+				progress := &progressReporter{repository: r.Name, callback: progressCallback}
+				results[idx] = p.pullRepo(ctx, r, ws, params, progress)
 			}
+		}()
+	}
 
-			return fmt.Errorf("failed to clone a git repository: %w", err)
-		}
+	for idx := range repos {
+		jobs <- idx
 	}
 
-	return nil
+	close(jobs)
+	wg.Wait()
+
+	return &PullResult{Repositories: results}
 }
 
-// createRepoDir creates the `repo` directory and returns whether it succeeded or not.
-func (p *Puller) createRepoDir(name string) error {
-	if _, err := os.Stat(name); os.IsNotExist(err) {
-		if err := os.Mkdir(name, os.ModePerm); err != nil {
-			return fmt.Errorf("failed to create a directory: %w", err)
-		}
+// pullRepo pulls the given repository into its workspace directory and returns its result.
+func (p *Puller) pullRepo(
+	ctx context.Context, r *types.Repository, ws *Workspace, params *PullParams, progress *progressReporter,
+) *RepositoryResult {
+	start := time.Now()
+	dir := ws.RepoDir(r)
+
+	if params.RepositoryTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, params.RepositoryTimeout)
+		defer cancel()
 	}
 
-	return nil
+	result := &RepositoryResult{
+		Name:          r.Name,
+		Path:          dir,
+		ReferenceName: r.ReferenceName,
+	}
+
+	req := &SourceRequest{
+		Repository: r,
+		Dir:        dir,
+		Workspace:  ws,
+		Params:     params,
+		progress:   progress,
+	}
+
+	action, err := p.pullSource(ctx, req)
+	result.Action = action
+
+	if err == nil {
+		err = result.inspect()
+	}
+
+	if err == nil {
+		err = result.verify(r)
+	}
+
+	if err != nil {
+		result.Err = redactError(fmt.Errorf("failed to pull repository %q: %w", r.Name, contextError(ctx, err)), r.URL)
+	}
+
+	result.Duration = time.Since(start)
+
+	return result
+}
+
+// pullSource pulls the requested repository using its source and returns the performed action.
+func (p *Puller) pullSource(ctx context.Context, req *SourceRequest) (PullAction, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	source, err := p.source(req.Repository)
+	if err != nil {
+		return 0, err
+	}
+
+	return source.Pull(ctx, req)
+}
+
+// contextError returns the given error wrapping the context error when the context is done,
+// so that callers can check for cancellations and timeouts.
+func contextError(ctx context.Context, err error) error {
+	ctxErr := ctx.Err()
+	if ctxErr == nil || errors.Is(err, ctxErr) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ctxErr, err)
 }
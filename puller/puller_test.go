@@ -1,7 +1,19 @@
 package puller
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 
 	"github.com/graphql-go/compatibility-base/types"
 )
@@ -38,3 +50,703 @@ func TestPullerPull(t *testing.T) {
 		t.Fatalf("expected: %+v, got: nil", expected)
 	}
 }
+
+func TestPullerPullReferenceName(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+
+	tests := []struct {
+		subTestName   string
+		referenceName string
+		expectedHash  plumbing.Hash
+	}{
+		{
+			subTestName:   "Handles annotated tag reference name",
+			referenceName: "v0.1.0",
+			expectedHash:  remote.first,
+		},
+		{
+			subTestName:   "Handles lightweight tag reference name",
+			referenceName: "v0.2.0",
+			expectedHash:  remote.second,
+		},
+		{
+			subTestName:   "Handles branch reference name",
+			referenceName: "release-0.1",
+			expectedHash:  remote.first,
+		},
+		{
+			subTestName:   "Handles commit hash reference name",
+			referenceName: remote.first.String(),
+			expectedHash:  remote.first,
+		},
+		{
+			subTestName:   "Handles abbreviated commit hash reference name",
+			referenceName: remote.first.String()[:7],
+			expectedHash:  remote.first,
+		},
+		{
+			subTestName:   "Handles empty reference name",
+			referenceName: "",
+			expectedHash:  remote.second,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			name := testRepoName(i)
+
+			_, err := New().Pull(&PullParams{
+				Workspace: ws,
+				Implementation: &types.Repository{
+					Name:          name,
+					URL:           remote.dir,
+					ReferenceName: tt.referenceName,
+				},
+			})
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			head := testHead(t, filepath.Join(ws.ReposDir(), name))
+			if head != tt.expectedHash {
+				t.Fatalf("expected: %s, got: %s", tt.expectedHash, head)
+			}
+		})
+	}
+}
+
+func TestPullerPullReferenceNotFound(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+	name := testRepoName(0)
+
+	_, err := New().Pull(&PullParams{
+		Workspace: ws,
+		Implementation: &types.Repository{
+			Name:          name,
+			URL:           remote.dir,
+			ReferenceName: "v9.9.9",
+		},
+	})
+
+	var notFoundErr *ReferenceNotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected: %T, got: %v", notFoundErr, err)
+	}
+
+	if notFoundErr.ReferenceName != "v9.9.9" {
+		t.Fatalf("expected: %s, got: %s", "v9.9.9", notFoundErr.ReferenceName)
+	}
+}
+
+func TestPullerPullExistingRepo(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		policy      ExistingRepoPolicy
+		expectedErr bool
+	}{
+		{
+			subTestName: "Handles default policy by fetching the existing clone",
+			policy:      0,
+		},
+		{
+			subTestName: "Handles fetch and reset policy",
+			policy:      FetchAndResetPolicy,
+		},
+		{
+			subTestName: "Handles re-clone policy",
+			policy:      ReclonePolicy,
+		},
+		{
+			subTestName: "Handles reuse policy without fetching",
+			policy:      ReusePolicy,
+			expectedErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			remote := newTestRemote(t)
+			ws := testWorkspace(t)
+			name := testRepoName(i)
+
+			repo := &types.Repository{
+				Name:          name,
+				URL:           remote.dir,
+				ReferenceName: "v0.2.0",
+			}
+
+			if _, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo}); err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			third := remote.commitAndTag(t, "v0.3.0")
+			repo.ReferenceName = "v0.3.0"
+
+			_, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo, ExistingRepoPolicy: tt.policy})
+			if tt.expectedErr {
+				var notFoundErr *ReferenceNotFoundError
+				if !errors.As(err, &notFoundErr) {
+					t.Fatalf("expected: %T, got: %v", notFoundErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			head := testHead(t, filepath.Join(ws.ReposDir(), name))
+			if head != third {
+				t.Fatalf("expected: %s, got: %s", third, head)
+			}
+		})
+	}
+}
+
+func TestPullerPullReuseLocalChanges(t *testing.T) {
+	tests := []struct {
+		subTestName   string
+		fileName      string
+		referenceName string
+		expectedErr   error
+		expectedHead  func(remote *testRemote) plumbing.Hash
+	}{
+		{
+			subTestName:   "Handles clean worktree at another reference name",
+			referenceName: "v0.1.0",
+			expectedHead:  func(remote *testRemote) plumbing.Hash { return remote.first },
+		},
+		{
+			subTestName:   "Handles modified file at another reference name",
+			fileName:      "README.md",
+			referenceName: "v0.1.0",
+			expectedErr:   ErrDirtyWorktree,
+			expectedHead:  func(remote *testRemote) plumbing.Hash { return remote.second },
+		},
+		{
+			subTestName:   "Handles modified file at the same reference name",
+			fileName:      "README.md",
+			referenceName: "v0.2.0",
+			expectedHead:  func(remote *testRemote) plumbing.Hash { return remote.second },
+		},
+		{
+			subTestName:   "Handles untracked file at another reference name",
+			fileName:      "notes.txt",
+			referenceName: "v0.1.0",
+			expectedErr:   ErrDirtyWorktree,
+			expectedHead:  func(remote *testRemote) plumbing.Hash { return remote.second },
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			remote := newTestRemote(t)
+			ws := testWorkspace(t)
+
+			repo := &types.Repository{Name: testRepoName(i), URL: remote.dir, ReferenceName: "v0.2.0"}
+
+			if _, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo}); err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			path := filepath.Join(ws.RepoDir(repo), tt.fileName)
+
+			if tt.fileName != "" {
+				if err := os.WriteFile(path, []byte("local change"), 0o644); err != nil {
+					t.Fatalf("failed to write file: %v", err)
+				}
+			}
+
+			repo.ReferenceName = tt.referenceName
+
+			_, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo, ExistingRepoPolicy: ReusePolicy})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected: %v, got: %v", tt.expectedErr, err)
+			}
+
+			if tt.fileName != "" {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("expected: local change to be kept, got: %v", err)
+				}
+
+				if string(content) != "local change" {
+					t.Fatalf("expected: %q, got: %q", "local change", string(content))
+				}
+			}
+
+			if head := testHead(t, ws.RepoDir(repo)); head != tt.expectedHead(remote) {
+				t.Fatalf("expected: %s, got: %s", tt.expectedHead(remote), head)
+			}
+		})
+	}
+}
+
+func TestPullerPullExistingRepoDoesNotSkipOthers(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+
+	existing := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0"}
+	if _, err := New().Pull(&PullParams{Workspace: ws, Specification: existing}); err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	other := &types.Repository{Name: testRepoName(1), URL: remote.dir, ReferenceName: "v0.2.0"}
+	if _, err := New().Pull(&PullParams{Workspace: ws, Specification: existing, Implementation: other}); err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	head := testHead(t, filepath.Join(ws.ReposDir(), other.Name))
+	if head != remote.second {
+		t.Fatalf("expected: %s, got: %s", remote.second, head)
+	}
+}
+
+func TestPullerPullRepositories(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+
+	repos := []types.Repository{
+		{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0"},
+		{Name: testRepoName(1), URL: remote.dir, ReferenceName: "v9.9.9"},
+		{Name: testRepoName(2), URL: remote.dir, ReferenceName: "v0.2.0"},
+		{Name: testRepoName(3), URL: remote.dir, ReferenceName: "v0.0.0"},
+	}
+
+	result, err := New().Pull(&PullParams{Workspace: ws, Repositories: repos, Concurrency: 2})
+
+	if result.Repository(repos[1].Name).Err == nil {
+		t.Fatalf("expected: error, got: nil")
+	}
+
+	var notFoundErr *ReferenceNotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected: %T, got: %v", notFoundErr, err)
+	}
+
+	for _, name := range []string{repos[1].Name, repos[3].Name} {
+		if !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected: error containing %q, got: %v", name, err)
+		}
+	}
+
+	expected := map[string]plumbing.Hash{
+		repos[0].Name: remote.first,
+		repos[2].Name: remote.second,
+	}
+
+	for name, hash := range expected {
+		head := testHead(t, filepath.Join(ws.ReposDir(), name))
+		if head != hash {
+			t.Fatalf("expected: %s, got: %s", hash, head)
+		}
+	}
+}
+
+func TestPullerPullDuplicatedRepositories(t *testing.T) {
+	ws := testWorkspace(t)
+	name := testRepoName(0)
+
+	_, err := New().Pull(&PullParams{
+		Workspace:      ws,
+		Implementation: &types.Repository{Name: name, URL: "https://example.com/a"},
+		Repositories:   []types.Repository{{Name: name, URL: "https://example.com/b"}},
+	})
+	if err == nil {
+		t.Fatalf("expected: error, got: nil")
+	}
+}
+
+func TestPullerPullResult(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+
+	tagged := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0"}
+	branch := &types.Repository{Name: testRepoName(1), URL: remote.dir}
+	params := &PullParams{Workspace: ws, Specification: tagged, Implementation: branch}
+
+	tests := []struct {
+		subTestName    string
+		expectedAction PullAction
+	}{
+		{
+			subTestName:    "Handles clone result",
+			expectedAction: CloneAction,
+		},
+		{
+			subTestName:    "Handles fetch result",
+			expectedAction: FetchAction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			result, err := New().Pull(params)
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			expected := []*RepositoryResult{
+				{
+					Name:          tagged.Name,
+					Path:          filepath.Join(ws.ReposDir(), tagged.Name),
+					ReferenceName: "v0.1.0",
+					Commit:        remote.first.String(),
+					Action:        tt.expectedAction,
+				},
+				{
+					Name:          branch.Name,
+					Path:          filepath.Join(ws.ReposDir(), branch.Name),
+					ReferenceName: "master",
+					Commit:        remote.second.String(),
+					Action:        tt.expectedAction,
+				},
+			}
+
+			for i, e := range expected {
+				r := result.Repositories[i]
+
+				if r.Size <= 0 {
+					t.Fatalf("expected: positive size, got: %d", r.Size)
+				}
+
+				if r.Duration <= 0 {
+					t.Fatalf("expected: positive duration, got: %s", r.Duration)
+				}
+
+				r.Size = 0
+				r.Duration = 0
+
+				if *r != *e {
+					t.Fatalf("expected: %+v, got: %+v", e, r)
+				}
+			}
+		})
+	}
+}
+
+func TestPullerPullRepositoryDir(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+	dir := filepath.Join(t.TempDir(), "nested", "graphql-graphql-js")
+
+	result, err := New().Pull(&PullParams{
+		Workspace: ws,
+		Implementation: &types.Repository{
+			Name:          testRepoName(0),
+			URL:           remote.dir,
+			ReferenceName: "v0.1.0",
+			Dir:           dir,
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	if result.Repositories[0].Path != dir {
+		t.Fatalf("expected: %s, got: %s", dir, result.Repositories[0].Path)
+	}
+
+	if head := testHead(t, dir); head != remote.first {
+		t.Fatalf("expected: %s, got: %s", remote.first, head)
+	}
+}
+
+func TestPullerPullContext(t *testing.T) {
+	remote := newTestRemote(t)
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		subTestName string
+		ctx         context.Context
+		params      *PullParams
+		expectedErr error
+	}{
+		{
+			subTestName: "Handles cancelled context",
+			ctx:         cancelledCtx,
+			params:      &PullParams{},
+			expectedErr: context.Canceled,
+		},
+		{
+			subTestName: "Handles overall timeout",
+			ctx:         context.Background(),
+			params:      &PullParams{Timeout: time.Nanosecond},
+			expectedErr: context.DeadlineExceeded,
+		},
+		{
+			subTestName: "Handles repository timeout",
+			ctx:         context.Background(),
+			params:      &PullParams{RepositoryTimeout: time.Nanosecond},
+			expectedErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			ws := testWorkspace(t)
+			repo := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0"}
+
+			tt.params.Workspace = ws
+			tt.params.Implementation = repo
+
+			_, err := New().PullContext(tt.ctx, tt.params)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected: %v, got: %v", tt.expectedErr, err)
+			}
+
+			if _, err := os.Stat(ws.RepoDir(repo)); !os.IsNotExist(err) {
+				t.Fatalf("expected: %s to be removed, got: %v", ws.RepoDir(repo), err)
+			}
+		})
+	}
+}
+
+func TestPullerPullContextExistingDir(t *testing.T) {
+	tests := []struct {
+		subTestName     string
+		populated       bool
+		expectedErr     error
+		expectedEntries []string
+	}{
+		{
+			subTestName:     "Handles cancelled clone into an empty directory",
+			expectedErr:     context.Canceled,
+			expectedEntries: []string{},
+		},
+		{
+			subTestName:     "Handles cancelled clone into a non-empty directory",
+			populated:       true,
+			expectedEntries: []string{"notes.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			remote := newTestRemote(t)
+			ws := testWorkspace(t)
+
+			dir := filepath.Join(t.TempDir(), "existing")
+			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+				t.Fatalf("failed to create directory: %v", err)
+			}
+
+			if tt.populated {
+				if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("keep"), 0o644); err != nil {
+					t.Fatalf("failed to write file: %v", err)
+				}
+			}
+
+			repo := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0", Dir: dir}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := New().PullContext(ctx, &PullParams{
+				Workspace:      ws,
+				Implementation: repo,
+				ProgressCallback: func(event ProgressEvent) {
+					if event.Phase == ReceivingPhase && event.Percentage == 100 {
+						cancel()
+					}
+				},
+			})
+			if err == nil {
+				t.Fatalf("expected: error, got: nil")
+			}
+
+			if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected: %v, got: %v", tt.expectedErr, err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("expected: existing directory to be kept, got: %v", err)
+			}
+
+			names := []string{}
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+
+			if !reflect.DeepEqual(names, tt.expectedEntries) {
+				t.Fatalf("expected: %v, got: %v", tt.expectedEntries, names)
+			}
+
+			if tt.populated {
+				content, err := os.ReadFile(filepath.Join(dir, "notes.txt"))
+				if err != nil || string(content) != "keep" {
+					t.Fatalf("expected: %q, got: %q, %v", "keep", string(content), err)
+				}
+			}
+		})
+	}
+}
+
+func TestPullerPullProgressCallback(t *testing.T) {
+	remote := newTestRemote(t)
+	ws := testWorkspace(t)
+	repo := &types.Repository{Name: testRepoName(0), URL: remote.dir, ReferenceName: "v0.1.0"}
+
+	phases := map[ProgressPhase]bool{}
+
+	_, err := New().Pull(&PullParams{
+		Workspace:      ws,
+		Implementation: repo,
+		ProgressCallback: func(event ProgressEvent) {
+			if event.Repository != repo.Name {
+				t.Errorf("expected: %s, got: %s", repo.Name, event.Repository)
+			}
+
+			phases[event.Phase] = true
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	for _, phase := range []ProgressPhase{CountingPhase, ReceivingPhase, CheckoutPhase} {
+		if !phases[phase] {
+			t.Fatalf("expected: %s progress event, got: %v", phase, phases)
+		}
+	}
+}
+
+// testRemote represents a local code repository used as a remote by the tests.
+type testRemote struct {
+	// dir is the code repository directory path.
+	dir string
+
+	// first is the first commit hash, tagged as `v0.1.0` and branched as `release-0.1`.
+	first plumbing.Hash
+
+	// second is the second commit hash, tagged as `v0.2.0` and pointed by `master`.
+	second plumbing.Hash
+}
+
+// newTestRemote creates a local code repository with two commits, tags and a branch.
+func newTestRemote(t *testing.T) *testRemote {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+
+	first := testCommit(t, repo, dir, "README.md", "first")
+	second := testCommit(t, repo, dir, "README.md", "second")
+
+	if _, err := repo.CreateTag("v0.1.0", first, &git.CreateTagOptions{
+		Tagger:  testSignature(),
+		Message: "v0.1.0",
+	}); err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+
+	refs := []*plumbing.Reference{
+		plumbing.NewHashReference(plumbing.NewTagReferenceName("v0.2.0"), second),
+		plumbing.NewHashReference(plumbing.NewBranchReferenceName("release-0.1"), first),
+	}
+
+	for _, ref := range refs {
+		if err := repo.Storer.SetReference(ref); err != nil {
+			t.Fatalf("failed to set reference: %v", err)
+		}
+	}
+
+	return &testRemote{dir: dir, first: first, second: second}
+}
+
+// commitAndTag adds a new commit to the test remote, tags it with the given name and returns its hash.
+func (r *testRemote) commitAndTag(t *testing.T, tagName string) plumbing.Hash {
+	t.Helper()
+
+	repo, err := git.PlainOpen(r.dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+
+	hash := testCommit(t, repo, r.dir, "README.md", tagName)
+
+	ref := plumbing.NewHashReference(plumbing.NewTagReferenceName(tagName), hash)
+	if err := repo.Storer.SetReference(ref); err != nil {
+		t.Fatalf("failed to set reference: %v", err)
+	}
+
+	return hash
+}
+
+// testCommit writes the given file content and commits it, returns the commit hash.
+func testCommit(t *testing.T, repo *git.Repository, dir string, fileName string, content string) plumbing.Hash {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	if _, err := w.Add(fileName); err != nil {
+		t.Fatalf("failed to add file: %v", err)
+	}
+
+	hash, err := w.Commit(content, &git.CommitOptions{Author: testSignature()})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	return hash
+}
+
+// testSignature returns the signature used by the test commits and tags.
+func testSignature() *object.Signature {
+	return &object.Signature{
+		Name:  "graphql-go",
+		Email: "graphql-go@example.com",
+		When:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// testRepoName returns the repository name used by the test with the given index.
+func testRepoName(idx int) string {
+	return fmt.Sprintf("repo-%d", idx)
+}
+
+// testWorkspace returns a workspace rooted at a temporary directory.
+func testWorkspace(t *testing.T) *Workspace {
+	t.Helper()
+
+	ws, err := NewWorkspace(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	return ws
+}
+
+// testHead returns the HEAD commit hash of the code repository at the given directory.
+func testHead(t *testing.T, dir string) plumbing.Hash {
+	t.Helper()
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get head: %v", err)
+	}
+
+	return head.Hash()
+}
@@ -0,0 +1,168 @@
+package puller
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// PullAction is the action performed for pulling a code repository.
+type PullAction uint
+
+const (
+	// CloneAction is the action of cloning a code repository.
+	CloneAction PullAction = iota + 1
+
+	// FetchAction is the action of fetching an already cloned code repository.
+	FetchAction
+
+	// ReuseAction is the action of reusing an already cloned code repository as is.
+	ReuseAction
+
+	// RecloneAction is the action of removing and cloning again a code repository.
+	RecloneAction
+)
+
+// String returns the string representation of the pull action.
+func (a PullAction) String() string {
+	switch a {
+	case CloneAction:
+		return "clone"
+	case FetchAction:
+		return "fetch"
+	case ReuseAction:
+		return "reuse"
+	case RecloneAction:
+		return "reclone"
+	default:
+		return "unknown"
+	}
+}
+
+// PullResult represents the result of the pull method.
+type PullResult struct {
+	// Repositories are the results of each code repository, in the same order as the pull parameters.
+	Repositories []*RepositoryResult
+}
+
+// Err returns the joined errors of the code repositories results.
+func (r *PullResult) Err() error {
+	errs := []error{}
+
+	for _, repo := range r.Repositories {
+		errs = append(errs, repo.Err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Repository returns the result of the code repository with the given name, nil when it is not found.
+func (r *PullResult) Repository(name string) *RepositoryResult {
+	for _, repo := range r.Repositories {
+		if repo.Name == name {
+			return repo
+		}
+	}
+
+	return nil
+}
+
+// RepositoryResult represents the pull result of a single code repository.
+type RepositoryResult struct {
+	// Name is the code repository name.
+	Name string
+
+	// Path is the local directory path of the code repository.
+	Path string
+
+	// ReferenceName is the reference name that was checked out, eg. a tag or a branch.
+	ReferenceName string
+
+	// Commit is the resolved commit hash that was checked out.
+	Commit string
+
+	// Action is the action performed for pulling the code repository.
+	Action PullAction
+
+	// Size is the size in bytes of the local directory of the code repository.
+	Size int64
+
+	// Duration is the time spent pulling the code repository.
+	Duration time.Duration
+
+	// Err is the error of pulling the code repository, nil when it succeeded.
+	Err error
+}
+
+// inspect fills the size and the commit hash of the local code repository, the commit hash is only filled
+// for git repositories.
+// The reference name is filled with the checked out branch when it was not given.
+func (r *RepositoryResult) inspect() error {
+	size, err := dirSize(r.Path)
+	if err != nil {
+		return err
+	}
+
+	r.Size = size
+
+	repo, err := git.PlainOpen(r.Path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to open a git repository: %w", err)
+	}
+
+	head, err := repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return fmt.Errorf("failed to get the head reference: %w", err)
+	}
+
+	if r.ReferenceName == "" && head.Type() == plumbing.SymbolicReference {
+		r.ReferenceName = head.Target().Short()
+	}
+
+	resolved, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get the head reference: %w", err)
+	}
+
+	r.Commit = resolved.Hash().String()
+
+	return nil
+}
+
+// dirSize returns the size in bytes of the regular files of the given directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute the directory size: %w", err)
+	}
+
+	return size, nil
+}
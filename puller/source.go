@@ -0,0 +1,66 @@
+package puller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// Source represents a backend that pulls a code repository into a local directory.
+type Source interface {
+	// Pull pulls the requested code repository into the requested directory and returns the performed action.
+	Pull(ctx context.Context, req *SourceRequest) (PullAction, error)
+}
+
+// SourceRequest represents the request of pulling a single code repository from a source.
+type SourceRequest struct {
+	// Repository is the code repository to pull.
+	Repository *types.Repository
+
+	// Dir is the local directory path where the code repository is pulled.
+	Dir string
+
+	// Workspace is the workspace where the code repositories are pulled.
+	Workspace *Workspace
+
+	// Params are the parameters of the pull.
+	Params *PullParams
+
+	// progress is the progress reporter of the code repository.
+	progress *progressReporter
+}
+
+// Progress reports the given progress event of the requested code repository.
+func (r *SourceRequest) Progress(event ProgressEvent) {
+	r.progress.emit(event)
+}
+
+// defaultSources returns the built-in sources keyed by their source type.
+func defaultSources() map[types.SourceType]Source {
+	return map[types.SourceType]Source{
+		types.GitSourceType:     &gitSource{},
+		types.ArchiveSourceType: &archiveSource{},
+		types.LocalSourceType:   &localSource{},
+	}
+}
+
+// source returns the source of the given code repository, the git source is used when the source type is not set.
+func (p *Puller) source(r *types.Repository) (Source, error) {
+	sourceType := r.Source
+	if sourceType == 0 {
+		sourceType = types.GitSourceType
+	}
+
+	source, ok := p.sources[sourceType]
+	if !ok {
+		return nil, fmt.Errorf("unexpected repository source type: %d", sourceType)
+	}
+
+	return source, nil
+}
+
+// WithSource updates the puller to pull the code repositories of the given source type using the given source.
+func (p *Puller) WithSource(sourceType types.SourceType, source Source) {
+	p.sources[sourceType] = source
+}
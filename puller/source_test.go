@@ -0,0 +1,428 @@
+package puller
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestPullerPullArchiveSource(t *testing.T) {
+	files := map[string]string{
+		"graphql-js-0.6.0/README.md":                      "graphql-js",
+		"graphql-js-0.6.0/src/__tests__/starWars-test.js": "describe('Star Wars')",
+	}
+
+	tarGz := testTarGz(t, files)
+	zipArchive := testZip(t, files)
+
+	archivesDir := t.TempDir()
+	tarGzPath := filepath.Join(archivesDir, "graphql-js.tar.gz")
+	zipPath := filepath.Join(archivesDir, "graphql-js.zip")
+
+	for path, content := range map[string][]byte{tarGzPath: tarGz, zipPath: zipArchive} {
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatalf("failed to write archive: %v", err)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(tarGz)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		subTestName  string
+		url          string
+		checksum     string
+		skipChecksum bool
+		offline      bool
+		expectedErr  error
+	}{
+		{
+			subTestName: "Handles local tar.gz archive",
+			url:         tarGzPath,
+			checksum:    testChecksum(tarGz),
+		},
+		{
+			subTestName: "Handles local zip archive",
+			url:         "file://" + zipPath,
+			checksum:    testChecksum(zipArchive),
+		},
+		{
+			subTestName: "Handles http archive",
+			url:         server.URL + "/graphql-js.tar.gz",
+			checksum:    testChecksum(tarGz),
+		},
+		{
+			subTestName:  "Handles http archive skipping the checksum",
+			url:          server.URL + "/graphql-js.tar.gz",
+			skipChecksum: true,
+		},
+		{
+			subTestName: "Handles missing checksum",
+			url:         tarGzPath,
+			expectedErr: ErrChecksumRequired,
+		},
+		{
+			subTestName: "Handles checksum mismatch",
+			url:         tarGzPath,
+			checksum:    testChecksum(zipArchive),
+			expectedErr: &VerificationError{},
+		},
+		{
+			subTestName: "Handles offline http archive",
+			url:         server.URL + "/graphql-js.tar.gz",
+			checksum:    testChecksum(tarGz),
+			offline:     true,
+			expectedErr: ErrNotAvailableOffline,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			ws := testWorkspace(t)
+			repo := &types.Repository{
+				Name:                 testRepoName(0),
+				URL:                  tt.url,
+				Source:               types.ArchiveSourceType,
+				Checksum:             tt.checksum,
+				InsecureSkipChecksum: tt.skipChecksum,
+			}
+
+			result, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo, Offline: tt.offline})
+
+			switch expectedErr := tt.expectedErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("expected: nil, got: %v", err)
+				}
+
+				content, err := os.ReadFile(filepath.Join(ws.RepoDir(repo), "src", "__tests__", "starWars-test.js"))
+				if err != nil {
+					t.Fatalf("expected: nil, got: %v", err)
+				}
+
+				assert.Equal(t, "describe('Star Wars')", string(content))
+				assert.Equal(t, CloneAction, result.Repositories[0].Action)
+			case *VerificationError:
+				if !errors.As(err, &expectedErr) {
+					t.Fatalf("expected: %T, got: %v", expectedErr, err)
+				}
+
+				assert.Equal(t, "checksum", expectedErr.Subject)
+			default:
+				if !errors.Is(err, expectedErr) {
+					t.Fatalf("expected: %v, got: %v", expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPullerPullArchiveSourceEscapingEntry(t *testing.T) {
+	ws := testWorkspace(t)
+	path := filepath.Join(t.TempDir(), "evil.tar.gz")
+
+	archive := testTarGz(t, map[string]string{"../evil.txt": "evil"})
+
+	if err := os.WriteFile(path, archive, 0o644); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	repo := &types.Repository{
+		Name:     testRepoName(0),
+		URL:      path,
+		Source:   types.ArchiveSourceType,
+		Checksum: testChecksum(archive),
+	}
+
+	if _, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo}); err == nil {
+		t.Fatalf("expected: error, got: nil")
+	}
+
+	if _, err := os.Stat(filepath.Join(ws.ReposDir(), "evil.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected: escaping entry not to be extracted, got: %v", err)
+	}
+}
+
+func TestPullerPullArchiveSourceChainedSymlinks(t *testing.T) {
+	ws := testWorkspace(t)
+	path := filepath.Join(t.TempDir(), "evil.tar.gz")
+
+	archive := testTarGzHeaders(t, []*tar.Header{
+		{Name: "d/", Mode: 0o755, Typeflag: tar.TypeDir},
+		{Name: "d/sub", Linkname: "..", Typeflag: tar.TypeSymlink},
+		{Name: "l1", Linkname: "d/sub/..", Typeflag: tar.TypeSymlink},
+		{Name: "l1/evil.txt", Mode: 0o644, Size: int64(len("evil")), Typeflag: tar.TypeReg},
+	}, map[string]string{"l1/evil.txt": "evil"})
+
+	if err := os.WriteFile(path, archive, 0o644); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	repo := &types.Repository{
+		Name:     testRepoName(0),
+		URL:      path,
+		Source:   types.ArchiveSourceType,
+		Checksum: testChecksum(archive),
+	}
+
+	if _, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo}); err == nil {
+		t.Fatalf("expected: error, got: nil")
+	}
+
+	if _, err := os.Stat(filepath.Join(ws.ReposDir(), "evil.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected: entry through symbolic links not to be extracted, got: %v", err)
+	}
+}
+
+func TestPullerPullArchiveSourceZipSymlinks(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		target      string
+		expectedErr bool
+	}{
+		{
+			subTestName: "Handles symbolic link inside the archive",
+			target:      "README.md",
+		},
+		{
+			subTestName: "Handles symbolic link escaping the archive",
+			target:      "../../evil.txt",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			ws := testWorkspace(t)
+			path := filepath.Join(t.TempDir(), "graphql-js.zip")
+
+			buf := bytes.Buffer{}
+			zw := zip.NewWriter(&buf)
+
+			entries := []struct {
+				header  *zip.FileHeader
+				content string
+			}{
+				{header: &zip.FileHeader{Name: "README.md"}, content: "graphql-js"},
+				{header: &zip.FileHeader{Name: "link"}, content: tt.target},
+			}
+
+			entries[1].header.SetMode(os.ModeSymlink | 0o777)
+
+			for _, entry := range entries {
+				w, err := zw.CreateHeader(entry.header)
+				if err != nil {
+					t.Fatalf("failed to create zip entry: %v", err)
+				}
+
+				if _, err := w.Write([]byte(entry.content)); err != nil {
+					t.Fatalf("failed to write zip content: %v", err)
+				}
+			}
+
+			if err := zw.Close(); err != nil {
+				t.Fatalf("failed to close zip: %v", err)
+			}
+
+			if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+				t.Fatalf("failed to write archive: %v", err)
+			}
+
+			repo := &types.Repository{
+				Name:     testRepoName(0),
+				URL:      path,
+				Source:   types.ArchiveSourceType,
+				Checksum: testChecksum(buf.Bytes()),
+			}
+
+			_, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo})
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			target, err := os.Readlink(filepath.Join(ws.RepoDir(repo), "link"))
+			if err != nil {
+				t.Fatalf("expected: symbolic link, got: %v", err)
+			}
+
+			assert.Equal(t, tt.target, target)
+		})
+	}
+}
+
+func TestPullerPullLocalSource(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		subTestName string
+		dir         string
+		expectedErr bool
+	}{
+		{
+			subTestName: "Handles existing local directory",
+			dir:         dir,
+		},
+		{
+			subTestName: "Handles missing local directory",
+			dir:         filepath.Join(dir, "missing"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			repo := &types.Repository{Name: testRepoName(0), Dir: tt.dir, Source: types.LocalSourceType}
+
+			result, err := New().Pull(&PullParams{Workspace: testWorkspace(t), Implementation: repo})
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, ReuseAction, result.Repositories[0].Action)
+			assert.Equal(t, tt.dir, result.Repositories[0].Path)
+		})
+	}
+}
+
+// testSource represents a source that records the pulled code repositories.
+type testSource struct {
+	// pulled are the names of the pulled code repositories.
+	pulled []string
+}
+
+// Pull records the requested code repository.
+func (s *testSource) Pull(_ context.Context, req *SourceRequest) (PullAction, error) {
+	s.pulled = append(s.pulled, req.Repository.Name)
+
+	return CloneAction, os.MkdirAll(req.Dir, os.ModePerm)
+}
+
+func TestPullerWithSource(t *testing.T) {
+	source := &testSource{}
+
+	puller := New()
+	puller.WithSource(types.GitSourceType, source)
+
+	repo := &types.Repository{Name: testRepoName(0), URL: "https://github.com/graphql/graphql-js"}
+
+	if _, err := puller.Pull(&PullParams{Workspace: testWorkspace(t), Implementation: repo}); err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, []string{repo.Name}, source.pulled)
+}
+
+// testTarGz returns a tar.gz archive of the given files content keyed by their path.
+func testTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	buf := bytes.Buffer{}
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar: %v", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// testTarGzHeaders returns a tar.gz archive of the given entries in order, with the given files content keyed by
+// their path.
+func testTarGzHeaders(t *testing.T, headers []*tar.Header, files map[string]string) []byte {
+	t.Helper()
+
+	buf := bytes.Buffer{}
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for _, header := range headers {
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+
+		if _, err := tw.Write([]byte(files[header.Name])); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar: %v", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// testZip returns a zip archive of the given files content keyed by their path.
+func testZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write zip content: %v", err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// testChecksum returns the sha256 checksum of the given content.
+func testChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return checksumPrefix + hex.EncodeToString(sum[:])
+}
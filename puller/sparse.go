@@ -0,0 +1,127 @@
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// errSparseNotSupported is the error returned when a code repository can not be sparsely cloned.
+var errSparseNotSupported = errors.New("sparse checkout is not supported")
+
+// sparseDirs returns the directories to sparsely check out for the given include path patterns,
+// which are the leading directories of the patterns before their first wildcard, eg. `src` for `src/**/__tests__`.
+// Returns nil when a pattern starts with a wildcard, so that the whole tree is checked out.
+// The patterns are not matched against the files: the whole leading directories are checked out,
+// eg. every file of `spec` for `spec/*.md`.
+func sparseDirs(patterns []string) []string {
+	dirs := []string{}
+
+	for _, pattern := range patterns {
+		segments := []string{}
+
+		for _, segment := range strings.Split(path.Clean(strings.Trim(pattern, "/")), "/") {
+			if strings.ContainsAny(segment, "*?[") {
+				break
+			}
+
+			segments = append(segments, segment)
+		}
+
+		if len(segments) == 0 || segments[0] == "." || segments[0] == ".." {
+			return nil
+		}
+
+		dirs = append(dirs, strings.Join(segments, "/"))
+	}
+
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	return dirs
+}
+
+// sparseCloneAndCheckout clones the requested repository with a shallow and single-branch clone,
+// then sparsely checks out its reference name.
+func (s *gitSource) sparseCloneAndCheckout(ctx context.Context, req *gitRequest) error {
+	referenceName, err := s.remoteReferenceName(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	repo, err := git.PlainCloneContext(ctx, req.Dir, false, &git.CloneOptions{
+		URL:           req.url,
+		Auth:          req.auth,
+		ReferenceName: referenceName,
+		SingleBranch:  true,
+		Depth:         1,
+		NoCheckout:    true,
+		Tags:          git.NoTags,
+		Progress:      req.progress.writer(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clone a git repository: %w: %w", errSparseNotSupported, err)
+	}
+
+	if size, err := dirSize(filepath.Join(req.Dir, git.GitDirName)); err == nil {
+		req.progress.emit(ProgressEvent{Phase: ReceivingPhase, Percentage: 100, Bytes: size})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if req.Repository.ReferenceName != "" {
+		return s.checkoutReference(repo, req.Repository, req.progress)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get the head reference: %w", err)
+	}
+
+	return s.checkout(repo, head.Hash(), req.Repository, true, req.progress)
+}
+
+// remoteReferenceName returns the full name of the requested reference name on the remote, the remote HEAD
+// when the reference name is empty.
+// Commit hashes can not be cloned with a single-branch clone, so they are not supported and fall back to a full clone.
+func (s *gitSource) remoteReferenceName(ctx context.Context, req *gitRequest) (plumbing.ReferenceName, error) {
+	if req.Repository.ReferenceName == "" {
+		return plumbing.HEAD, nil
+	}
+
+	refs, err := listRemoteReferences(ctx, req.url, req.auth)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errSparseNotSupported, err)
+	}
+
+	candidates := []plumbing.ReferenceName{
+		plumbing.NewTagReferenceName(req.Repository.ReferenceName),
+		plumbing.NewBranchReferenceName(req.Repository.ReferenceName),
+	}
+
+	for _, c := range candidates {
+		for _, ref := range refs {
+			if ref.Name() == c {
+				return c, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("failed to find the remote reference %q: %w", req.Repository.ReferenceName, errSparseNotSupported)
+}
+
+// isShallow returns whether the given repository is a shallow clone.
+func isShallow(repo *git.Repository) bool {
+	shallow, err := repo.Storer.Shallow()
+
+	return err == nil && len(shallow) > 0
+}
@@ -0,0 +1,176 @@
+package puller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestSparseDirs(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		patterns    []string
+		expected    []string
+	}{
+		{
+			subTestName: "Handles empty patterns",
+			expected:    nil,
+		},
+		{
+			subTestName: "Handles wildcard patterns",
+			patterns:    []string{"src/**/__tests__", "spec/*.md"},
+			expected:    []string{"src", "spec"},
+		},
+		{
+			subTestName: "Handles directory patterns",
+			patterns:    []string{"/src/language/"},
+			expected:    []string{"src/language"},
+		},
+		{
+			subTestName: "Handles leading wildcard pattern",
+			patterns:    []string{"src", "*.md"},
+			expected:    nil,
+		},
+		{
+			subTestName: "Handles escaping pattern",
+			patterns:    []string{"../src"},
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sparseDirs(tt.patterns))
+		})
+	}
+}
+
+func TestPullerPullIncludePaths(t *testing.T) {
+	remoteDir, hash := newTestSparseRemote(t)
+
+	tests := []struct {
+		subTestName     string
+		referenceName   string
+		existingDir     bool
+		expectedShallow bool
+	}{
+		{
+			subTestName:     "Handles tag reference name",
+			referenceName:   "v0.1.0",
+			expectedShallow: true,
+		},
+		{
+			subTestName:     "Handles branch reference name",
+			referenceName:   "master",
+			expectedShallow: true,
+		},
+		{
+			subTestName:     "Handles default branch",
+			expectedShallow: true,
+		},
+		{
+			subTestName:     "Handles commit hash fallback to full clone",
+			referenceName:   hash.String(),
+			expectedShallow: false,
+		},
+		{
+			subTestName:     "Handles commit hash fallback to full clone into an existing directory",
+			referenceName:   hash.String(),
+			existingDir:     true,
+			expectedShallow: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			ws := testWorkspace(t)
+
+			repo := &types.Repository{
+				Name:          testRepoName(0),
+				URL:           remoteDir,
+				ReferenceName: tt.referenceName,
+				IncludePaths:  []string{"src/**/__tests__", "spec/*.md"},
+			}
+
+			if tt.existingDir {
+				repo.Dir = filepath.Join(t.TempDir(), "existing")
+				if err := os.MkdirAll(repo.Dir, os.ModePerm); err != nil {
+					t.Fatalf("failed to create directory: %v", err)
+				}
+			}
+
+			result, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo})
+			if err != nil {
+				t.Fatalf("failed to pull: %v", err)
+			}
+
+			dir := ws.RepoDir(repo)
+
+			assert.Equal(t, hash, testHead(t, dir))
+			assert.Equal(t, hash.String(), result.Repository(repo.Name).Commit)
+			assert.FileExists(t, filepath.Join(dir, "src", "language", "__tests__", "lexer-test.js"))
+			assert.FileExists(t, filepath.Join(dir, "spec", "Section 1 -- Overview.md"))
+			// Patterns are checked out with the granularity of their leading directory.
+			assert.FileExists(t, filepath.Join(dir, "spec", "README.txt"))
+
+			clonedRepo, err := git.PlainOpen(dir)
+			if err != nil {
+				t.Fatalf("failed to open repository: %v", err)
+			}
+
+			assert.Equal(t, tt.expectedShallow, isShallow(clonedRepo))
+
+			assert.NoFileExists(t, filepath.Join(dir, "website", "index.md"))
+
+			if _, err := New().Pull(&PullParams{Workspace: ws, Implementation: repo}); err != nil {
+				t.Fatalf("failed to pull again: %v", err)
+			}
+
+			assert.Equal(t, hash, testHead(t, dir))
+		})
+	}
+}
+
+// newTestSparseRemote creates a local code repository with a single commit tagged as `v0.1.0`,
+// returns its directory and the commit hash.
+func newTestSparseRemote(t *testing.T) (string, plumbing.Hash) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+
+	files := []string{
+		filepath.Join("src", "language", "__tests__", "lexer-test.js"),
+		filepath.Join("spec", "Section 1 -- Overview.md"),
+		filepath.Join("spec", "README.txt"),
+		filepath.Join("website", "index.md"),
+	}
+
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(f)), os.ModePerm); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+	}
+
+	hash := plumbing.ZeroHash
+	for _, f := range files {
+		hash = testCommit(t, repo, dir, f, f)
+	}
+
+	ref := plumbing.NewHashReference(plumbing.NewTagReferenceName("v0.1.0"), hash)
+	if err := repo.Storer.SetReference(ref); err != nil {
+		t.Fatalf("failed to set reference: %v", err)
+	}
+
+	return dir, hash
+}
@@ -0,0 +1,131 @@
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/graphql-go/compatibility-base/tags"
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// ListTagsParams represents the parameters of the list tags method.
+type ListTagsParams struct {
+	// Repository is the git code repository whose tags are listed.
+	Repository *types.Repository
+
+	// Cache is the optional cache of bare mirrors, the tags are listed from the updated mirror when it is set.
+	Cache *Cache
+
+	// Offline represents whether or not the tags are listed without network access, only from the cache.
+	Offline bool
+}
+
+// ListTags returns the sorted tag names of the given git code repository, from a remote, a local code repository
+// or the cached mirror.
+func (p *Puller) ListTags(ctx context.Context, params *ListTagsParams) ([]string, error) {
+	r := params.Repository
+	if r == nil {
+		return nil, errors.New("failed to list tags: nil repository")
+	}
+
+	if params.Cache == nil && params.Offline {
+		return nil, fmt.Errorf("failed to list the tags of %q: %w", r.Name, ErrNotAvailableOffline)
+	}
+
+	var auth transport.AuthMethod
+
+	if !params.Offline {
+		var err error
+
+		auth, err = resolveAuth(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if params.Cache == nil {
+		return listRemoteTags(ctx, r.URL, auth)
+	}
+
+	mirrorDir, err := params.Cache.mirror(ctx, r.URL, params.Offline, auth, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := git.PlainOpen(mirrorDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a git mirror: %w", err)
+	}
+
+	refs, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the mirror tags: %w", err)
+	}
+
+	names := []string{}
+
+	if err := refs.ForEach(func(ref *plumbing.Reference) error {
+		names = append(names, ref.Name().Short())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list the mirror tags: %w", err)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// ResolveReferenceName returns the tag name of the given git code repository that matches the given version
+// expression, eg. `latest`, `latest v0.x` or `newest spec edition`.
+func (p *Puller) ResolveReferenceName(ctx context.Context, params *ListTagsParams, expression string) (string, error) {
+	names, err := p.ListTags(ctx, params)
+	if err != nil {
+		return "", err
+	}
+
+	return tags.Select(names, expression)
+}
+
+// listRemoteTags returns the sorted tag names of the git remote with the given URL.
+func listRemoteTags(ctx context.Context, url string, auth transport.AuthMethod) ([]string, error) {
+	refs, err := listRemoteReferences(ctx, url, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			names = append(names, ref.Name().Short())
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// listRemoteReferences returns the references of the git remote with the given URL.
+func listRemoteReferences(ctx context.Context, url string, auth transport.AuthMethod) ([]*plumbing.Reference, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{url},
+	})
+
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the remote references: %w", err)
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,98 @@
+package puller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestPullerListTags(t *testing.T) {
+	remote := newTestRemote(t)
+	remote.commitAndTag(t, "October2021")
+
+	repo := &types.Repository{Name: testRepoName(0), URL: remote.dir}
+	expected := []string{"October2021", "v0.1.0", "v0.2.0"}
+
+	tests := []struct {
+		subTestName string
+		cache       *Cache
+	}{
+		{
+			subTestName: "Handles remote tags",
+		},
+		{
+			subTestName: "Handles cached mirror tags",
+			cache:       testCache(t),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			names, err := New().ListTags(context.Background(), &ListTagsParams{Repository: repo, Cache: tt.cache})
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.Equal(t, expected, names)
+		})
+	}
+
+	t.Run("Handles offline cached mirror tags", func(t *testing.T) {
+		cache := testCache(t)
+
+		_, err := New().ListTags(context.Background(), &ListTagsParams{Repository: repo, Cache: cache, Offline: true})
+		if !errors.Is(err, ErrNotAvailableOffline) {
+			t.Fatalf("expected: %v, got: %v", ErrNotAvailableOffline, err)
+		}
+
+		if _, err := New().ListTags(context.Background(), &ListTagsParams{Repository: repo, Cache: cache}); err != nil {
+			t.Fatalf("expected: nil, got: %v", err)
+		}
+
+		names, err := New().ListTags(context.Background(), &ListTagsParams{Repository: repo, Cache: cache, Offline: true})
+		if err != nil {
+			t.Fatalf("expected: nil, got: %v", err)
+		}
+
+		assert.Equal(t, expected, names)
+	})
+}
+
+func TestPullerResolveReferenceName(t *testing.T) {
+	remote := newTestRemote(t)
+	remote.commitAndTag(t, "October2021")
+
+	params := &ListTagsParams{Repository: &types.Repository{Name: testRepoName(0), URL: remote.dir}}
+
+	tests := []struct {
+		subTestName string
+		expression  string
+		expected    string
+	}{
+		{
+			subTestName: "Handles latest",
+			expression:  "latest",
+			expected:    "v0.2.0",
+		},
+		{
+			subTestName: "Handles newest spec edition",
+			expression:  "newest spec edition",
+			expected:    "October2021",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			name, err := New().ResolveReferenceName(context.Background(), params, tt.expression)
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.Equal(t, tt.expected, name)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package puller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// minAbbreviatedHashLen is the minimum length of an abbreviated pinned commit hash.
+const minAbbreviatedHashLen = 7
+
+// verify verifies the pulled content of the given code repository against its pinned commit and tree hash.
+// For git repositories the tree hash is the tree of the checked out commit,
+// for other directories the tree hash is computed from the directory content like `git write-tree` does.
+func (r *RepositoryResult) verify(repo *types.Repository) error {
+	if repo.Commit == "" && repo.TreeHash == "" {
+		return nil
+	}
+
+	gitRepo, err := git.PlainOpen(r.Path)
+	if err != nil && !errors.Is(err, git.ErrRepositoryNotExists) {
+		return fmt.Errorf("failed to open a git repository: %w", err)
+	}
+
+	if repo.Commit != "" && !commitMatches(repo.Commit, r.Commit) {
+		actual := r.Commit
+		if actual == "" {
+			actual = "none"
+		}
+
+		return &VerificationError{Repository: repo.Name, Subject: "commit", Expected: repo.Commit, Actual: actual}
+	}
+
+	if repo.TreeHash == "" {
+		return nil
+	}
+
+	var tree plumbing.Hash
+
+	if gitRepo != nil {
+		commit, err := gitRepo.CommitObject(plumbing.NewHash(r.Commit))
+		if err != nil {
+			return fmt.Errorf("failed to get the commit: %w", err)
+		}
+
+		tree = commit.TreeHash
+	} else {
+		tree, err = dirTreeHash(r.Path)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !strings.EqualFold(repo.TreeHash, tree.String()) {
+		return &VerificationError{Repository: repo.Name, Subject: "tree", Expected: repo.TreeHash, Actual: tree.String()}
+	}
+
+	return nil
+}
+
+// commitMatches returns whether the actual commit hash matches the expected full or abbreviated commit hash.
+func commitMatches(expected string, actual string) bool {
+	if len(expected) < minAbbreviatedHashLen || actual == "" {
+		return false
+	}
+
+	return strings.HasPrefix(actual, strings.ToLower(expected))
+}
+
+// dirTreeHash returns the git tree hash of the given directory content, `.git` directories are ignored.
+func dirTreeHash(dir string) (plumbing.Hash, error) {
+	hash, _, err := treeHash(dir)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to compute the tree hash: %w", err)
+	}
+
+	return hash, nil
+}
+
+// treeHash returns the git tree hash of the given directory and whether or not the tree is empty,
+// empty directories are not part of git trees.
+func treeHash(dir string) (plumbing.Hash, bool, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return plumbing.ZeroHash, false, err
+	}
+
+	entries := []object.TreeEntry{}
+
+	for _, e := range dirEntries {
+		if e.Name() == git.GitDirName {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+
+		entry, ok, err := treeEntry(path, e)
+		if err != nil {
+			return plumbing.ZeroHash, false, err
+		}
+
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) == 0 {
+		return plumbing.ZeroHash, true, nil
+	}
+
+	sort.Sort(object.TreeEntrySorter(entries))
+
+	obj := &plumbing.MemoryObject{}
+	if err := (&object.Tree{Entries: entries}).Encode(obj); err != nil {
+		return plumbing.ZeroHash, false, err
+	}
+
+	return obj.Hash(), false, nil
+}
+
+// treeEntry returns the git tree entry of the given directory entry and whether or not it is part of the tree.
+func treeEntry(path string, e os.DirEntry) (object.TreeEntry, bool, error) {
+	info, err := e.Info()
+	if err != nil {
+		return object.TreeEntry{}, false, err
+	}
+
+	switch {
+	case info.IsDir():
+		hash, empty, err := treeHash(path)
+		if err != nil || empty {
+			return object.TreeEntry{}, false, err
+		}
+
+		return object.TreeEntry{Name: e.Name(), Mode: filemode.Dir, Hash: hash}, true, nil
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(path)
+		if err != nil {
+			return object.TreeEntry{}, false, err
+		}
+
+		hash := plumbing.ComputeHash(plumbing.BlobObject, []byte(filepath.ToSlash(target)))
+
+		return object.TreeEntry{Name: e.Name(), Mode: filemode.Symlink, Hash: hash}, true, nil
+	case info.Mode().IsRegular():
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return object.TreeEntry{}, false, err
+		}
+
+		mode := filemode.Regular
+		if info.Mode().Perm()&0o111 != 0 {
+			mode = filemode.Executable
+		}
+
+		hash := plumbing.ComputeHash(plumbing.BlobObject, content)
+
+		return object.TreeEntry{Name: e.Name(), Mode: mode, Hash: hash}, true, nil
+	default:
+		return object.TreeEntry{}, false, nil
+	}
+}
@@ -0,0 +1,142 @@
+package puller
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestPullerPullVerification(t *testing.T) {
+	remote := newTestRemote(t)
+
+	remoteRepo, err := git.PlainOpen(remote.dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+
+	first, err := remoteRepo.CommitObject(remote.first)
+	if err != nil {
+		t.Fatalf("failed to get commit: %v", err)
+	}
+
+	tests := []struct {
+		subTestName     string
+		commit          string
+		treeHash        string
+		expectedSubject string
+	}{
+		{
+			subTestName: "Handles matching pinned commit",
+			commit:      remote.first.String(),
+		},
+		{
+			subTestName: "Handles matching abbreviated pinned commit",
+			commit:      remote.first.String()[:7],
+		},
+		{
+			subTestName: "Handles matching pinned commit and tree hash",
+			commit:      remote.first.String(),
+			treeHash:    first.TreeHash.String(),
+		},
+		{
+			subTestName:     "Handles moved tag pinned commit",
+			commit:          remote.second.String(),
+			expectedSubject: "commit",
+		},
+		{
+			subTestName:     "Handles mismatching pinned tree hash",
+			treeHash:        remote.second.String(),
+			expectedSubject: "tree",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			repo := &types.Repository{
+				Name:          testRepoName(0),
+				URL:           remote.dir,
+				ReferenceName: "v0.1.0",
+				Commit:        tt.commit,
+				TreeHash:      tt.treeHash,
+			}
+
+			_, err := New().Pull(&PullParams{Workspace: testWorkspace(t), Implementation: repo})
+			if tt.expectedSubject == "" {
+				assert.Nil(t, err)
+				return
+			}
+
+			var verificationErr *VerificationError
+			if !errors.As(err, &verificationErr) {
+				t.Fatalf("expected: %T, got: %v", verificationErr, err)
+			}
+
+			assert.Equal(t, tt.expectedSubject, verificationErr.Subject)
+		})
+	}
+}
+
+func TestDirTreeHash(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]os.FileMode{
+		"README.md":                      0o644,
+		"bin/test.sh":                    0o755,
+		"src/__tests__/starWars-test.js": 0o644,
+		"src/index.js":                   0o644,
+		"src-index.js":                   0o644,
+	}
+
+	for name, mode := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(name), mode); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "empty"), os.ModePerm); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	if err := w.AddGlob("."); err != nil {
+		t.Fatalf("failed to add files: %v", err)
+	}
+
+	hash, err := w.Commit("tree", &git.CommitOptions{Author: testSignature()})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		t.Fatalf("failed to get commit: %v", err)
+	}
+
+	tree, err := dirTreeHash(dir)
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, commit.TreeHash, tree)
+}
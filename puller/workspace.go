@@ -0,0 +1,68 @@
+package puller
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// Workspace represents the directory tree where the code repositories are pulled.
+type Workspace struct {
+	// root is the absolute path of the workspace root directory.
+	root string
+}
+
+// NewWorkspace returns a pointer to a Workspace struct rooted at the given directory.
+// An empty root defaults to the current working directory.
+func NewWorkspace(root string) (*Workspace, error) {
+	if root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the working directory: %w", err)
+		}
+
+		root = wd
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the workspace root: %w", err)
+	}
+
+	return &Workspace{root: absRoot}, nil
+}
+
+// Root returns the absolute path of the workspace root directory.
+func (w *Workspace) Root() string {
+	return w.root
+}
+
+// ReposDir returns the absolute path of the `repos` directory of the workspace.
+func (w *Workspace) ReposDir() string {
+	return filepath.Join(w.root, reposDirName)
+}
+
+// RepoDir returns the absolute directory path of the given code repository.
+// The repository `Dir` overrides the default `repos/<name>` path, relative values are resolved from the workspace root.
+func (w *Workspace) RepoDir(r *types.Repository) string {
+	if r.Dir == "" {
+		return filepath.Join(w.ReposDir(), r.Name)
+	}
+
+	if filepath.IsAbs(r.Dir) {
+		return filepath.Clean(r.Dir)
+	}
+
+	return filepath.Join(w.root, r.Dir)
+}
+
+// createReposDir creates the `repos` directory of the workspace and returns whether it succeeded or not.
+func (w *Workspace) createReposDir() error {
+	if err := os.MkdirAll(w.ReposDir(), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create a directory: %w", err)
+	}
+
+	return nil
+}
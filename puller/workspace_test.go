@@ -0,0 +1,64 @@
+package puller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestNewWorkspace(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	ws, err := NewWorkspace("")
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	assert.Equal(t, wd, ws.Root())
+	assert.Equal(t, filepath.Join(wd, reposDirName), ws.ReposDir())
+}
+
+func TestWorkspaceRepoDir(t *testing.T) {
+	root := t.TempDir()
+	absDir := filepath.Join(t.TempDir(), "graphql-go-graphql")
+
+	ws, err := NewWorkspace(root)
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	tests := []struct {
+		subTestName string
+		repo        *types.Repository
+		expectedDir string
+	}{
+		{
+			subTestName: "Handles repository without directory",
+			repo:        &types.Repository{Name: "graphql-go-graphql"},
+			expectedDir: filepath.Join(root, "repos", "graphql-go-graphql"),
+		},
+		{
+			subTestName: "Handles repository with relative directory",
+			repo:        &types.Repository{Name: "graphql-go-graphql", Dir: "./repos/graphql-go-graphql/"},
+			expectedDir: filepath.Join(root, "repos", "graphql-go-graphql"),
+		},
+		{
+			subTestName: "Handles repository with absolute directory",
+			repo:        &types.Repository{Name: "graphql-go-graphql", Dir: absDir},
+			expectedDir: absDir,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			assert.Equal(t, tt.expectedDir, ws.RepoDir(tt.repo))
+		})
+	}
+}
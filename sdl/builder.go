@@ -0,0 +1,875 @@
+package sdl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// specifiedDocument is the document of the scalars, the directives and the introspection types specified by graphql.
+var specifiedDocument = mustParseDocument(specifiedDefinitions)
+
+// mustParseDocument parses the given type system document source, it panics on syntax errors.
+func mustParseDocument(source string) *document {
+	doc, err := parseDocument(source)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse the specified definitions: %v", err))
+	}
+
+	return doc
+}
+
+// SchemaError represents an error of a valid graphql source which does not define a valid schema,
+// at a line and column, both starting at 1.
+type SchemaError struct {
+	// Message is the error message.
+	Message string
+
+	// Line is the line of the error.
+	Line int
+
+	// Column is the column of the error.
+	Column int
+}
+
+// Error returns the error message with its location.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("schema error at %d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// newSchemaError returns the schema error of the given message at the given location.
+func newSchemaError(l location, format string, args ...any) *SchemaError {
+	return &SchemaError{Message: fmt.Sprintf(format, args...), Line: l.line, Column: l.column}
+}
+
+// Parser represents the parser of graphql SDL into introspection schemas.
+type Parser struct {
+}
+
+// NewParser returns a pointer to a Parser struct.
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Parse returns the introspection query result of the schema defined by the given SDL source, as graphql-js
+// introspects the schema built by `buildASTSchema`: the type system extensions are applied, the referenced
+// specified scalars, the specified directives and the introspection types are included, and the default values
+// are normalized. Syntax errors are returned as *SyntaxError and invalid schemas as *SchemaError.
+func (p *Parser) Parse(source string) (*types.IntrospectionQueryResult, error) {
+	doc, err := parseDocument(source)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newSchemaBuilder()
+
+	if err := b.addDocument(doc); err != nil {
+		return nil, err
+	}
+
+	schema, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.IntrospectionQueryResult{Schema: *schema}, nil
+}
+
+// schemaBuilder represents the builder of an introspection schema from type system documents.
+type schemaBuilder struct {
+	// specified are the specified type definitions, keyed by name.
+	specified map[string]*typeDefinition
+
+	// defined are the type definitions of the document, keyed by name.
+	defined map[string]*typeDefinition
+
+	// names are the type names of the document, in definition order.
+	names []string
+
+	// directives are the directive definitions of the document, in definition order.
+	directives []*directiveDefinition
+
+	// schema is the schema definition of the document, nil when it has none.
+	schema *schemaDefinition
+
+	// operationTypes are the root operation types of the schema definition and extensions, keyed by operation.
+	operationTypes map[string]*typeNode
+}
+
+// newSchemaBuilder returns a pointer to a schemaBuilder struct.
+func newSchemaBuilder() *schemaBuilder {
+	specified := map[string]*typeDefinition{}
+	for _, def := range specifiedDocument.types {
+		specified[def.name] = def
+	}
+
+	return &schemaBuilder{
+		specified:      specified,
+		defined:        map[string]*typeDefinition{},
+		operationTypes: map[string]*typeNode{},
+	}
+}
+
+// addDocument adds the definitions of the given document, then applies its extensions.
+func (b *schemaBuilder) addDocument(doc *document) error {
+	for _, def := range doc.types {
+		if def.extension {
+			continue
+		}
+
+		if strings.HasPrefix(def.name, "__") {
+			return newSchemaError(def.location, "name %q must not begin with \"__\"", def.name)
+		}
+
+		if _, ok := b.defined[def.name]; ok {
+			return newSchemaError(def.location, "there can be only one type named %q", def.name)
+		}
+
+		if specifiedScalarNames[def.name] {
+			b.defined[def.name] = b.specified[def.name]
+		} else {
+			b.defined[def.name] = def
+		}
+
+		b.names = append(b.names, def.name)
+	}
+
+	for _, def := range doc.types {
+		if !def.extension {
+			continue
+		}
+
+		if err := b.extend(def); err != nil {
+			return err
+		}
+	}
+
+	directiveNames := map[string]bool{}
+
+	for _, def := range doc.directives {
+		if directiveNames[def.name] {
+			return newSchemaError(def.location, "there can be only one directive named \"@%s\"", def.name)
+		}
+
+		directiveNames[def.name] = true
+		b.directives = append(b.directives, def)
+	}
+
+	for _, def := range specifiedDocument.directives {
+		if !directiveNames[def.name] {
+			b.directives = append(b.directives, def)
+		}
+	}
+
+	for _, def := range doc.schemas {
+		if !def.extension {
+			if b.schema != nil {
+				return newSchemaError(def.location, "must provide only one schema definition")
+			}
+
+			b.schema = def
+		}
+
+		for _, op := range def.operationTypes {
+			if _, ok := b.operationTypes[op.operation]; ok {
+				return newSchemaError(op.typeName.location, "there can be only one %s type in schema", op.operation)
+			}
+
+			b.operationTypes[op.operation] = op.typeName
+		}
+	}
+
+	if b.schema == nil {
+		for _, name := range b.names {
+			switch name {
+			case "Query":
+				b.operationTypes["query"] = &typeNode{name: name}
+			case "Mutation":
+				b.operationTypes["mutation"] = &typeNode{name: name}
+			case "Subscription":
+				b.operationTypes["subscription"] = &typeNode{name: name}
+			}
+		}
+	}
+
+	return nil
+}
+
+// extend applies the given type extension to its defined type.
+func (b *schemaBuilder) extend(ext *typeDefinition) error {
+	def, ok := b.defined[ext.name]
+	if !ok || specifiedScalarNames[ext.name] {
+		return newSchemaError(ext.location, "cannot extend type %q because it is not defined", ext.name)
+	}
+
+	if def.kind != ext.kind {
+		return newSchemaError(ext.location, "cannot extend non-%s type %q", ext.kind, ext.name)
+	}
+
+	def.directives = append(def.directives, ext.directives...)
+	def.interfaces = append(def.interfaces, ext.interfaces...)
+	def.fields = append(def.fields, ext.fields...)
+	def.inputFields = append(def.inputFields, ext.inputFields...)
+	def.values = append(def.values, ext.values...)
+	def.members = append(def.members, ext.members...)
+
+	return nil
+}
+
+// lookup returns the type definition of the given name, the document definitions first.
+func (b *schemaBuilder) lookup(name string) (*typeDefinition, bool) {
+	if def, ok := b.defined[name]; ok {
+		return def, true
+	}
+
+	def, ok := b.specified[name]
+
+	return def, ok
+}
+
+// build returns the introspection schema of the added documents.
+func (b *schemaBuilder) build() (*types.IntrospectionSchema, error) {
+	schema := &types.IntrospectionSchema{Directives: []types.IntrospectionDirective{}}
+
+	if b.schema != nil {
+		schema.Description = b.schema.description
+	}
+
+	roots := map[string]*types.IntrospectionNamedTypeRef{}
+
+	for _, operation := range []string{"query", "mutation", "subscription"} {
+		node, ok := b.operationTypes[operation]
+		if !ok {
+			continue
+		}
+
+		def, ok := b.lookup(node.name)
+		if !ok {
+			return nil, newSchemaError(node.location, "unknown type %q", node.name)
+		}
+
+		if def.kind != types.ObjectTypeKind {
+			return nil, newSchemaError(node.location, "%s root type %q must be an object type", operation, node.name)
+		}
+
+		roots[operation] = types.NewNamedTypeRef(def.kind, def.name)
+	}
+
+	if root, ok := roots["query"]; ok {
+		schema.QueryType = *root
+	}
+
+	schema.MutationType = roots["mutation"]
+	schema.SubscriptionType = roots["subscription"]
+
+	names, err := b.typeNames()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		def, _ := b.lookup(name)
+
+		t, err := b.buildType(def)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.Types = append(schema.Types, t)
+	}
+
+	b.addPossibleTypes(schema.Types)
+
+	for _, def := range b.directives {
+		d, err := b.buildDirective(def)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.Directives = append(schema.Directives, *d)
+	}
+
+	normalizeDefaultValues(schema)
+
+	return schema, nil
+}
+
+// typeNames returns the names of the schema types, ordered as graphql-js collects the referenced types:
+// the document types first, then the types referenced by the root types, the directives and `__Schema`.
+func (b *schemaBuilder) typeNames() ([]string, error) {
+	c := &typeCollector{builder: b, present: map[string]bool{}}
+
+	for _, name := range b.names {
+		c.present[name] = true
+	}
+
+	c.names = append(c.names, b.names...)
+
+	for _, name := range b.names {
+		c.remove(name)
+
+		if err := c.collect(&typeNode{name: name}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, operation := range []string{"query", "mutation", "subscription"} {
+		if node, ok := b.operationTypes[operation]; ok {
+			if err := c.collect(node); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, def := range b.directives {
+		for _, arg := range def.args {
+			if err := c.collect(arg.typeRef); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := c.collect(&typeNode{name: "__Schema"}); err != nil {
+		return nil, err
+	}
+
+	return c.names, nil
+}
+
+// typeCollector represents the ordered set of the referenced type names.
+type typeCollector struct {
+	// builder is the schema builder.
+	builder *schemaBuilder
+
+	// names are the collected type names, in collection order.
+	names []string
+
+	// present is whether a type name is collected.
+	present map[string]bool
+}
+
+// remove removes the given type name from the collected type names.
+func (c *typeCollector) remove(name string) {
+	for i, n := range c.names {
+		if n == name {
+			c.names = append(c.names[:i], c.names[i+1:]...)
+			break
+		}
+	}
+
+	delete(c.present, name)
+}
+
+// collect adds the named type of the given type reference and the types it references, when it is not collected.
+func (c *typeCollector) collect(node *typeNode) error {
+	for node.ofType != nil {
+		node = node.ofType
+	}
+
+	if c.present[node.name] {
+		return nil
+	}
+
+	def, ok := c.builder.lookup(node.name)
+	if !ok {
+		return newSchemaError(node.location, "unknown type %q", node.name)
+	}
+
+	c.present[node.name] = true
+	c.names = append(c.names, node.name)
+
+	for _, member := range def.members {
+		if err := c.collect(member); err != nil {
+			return err
+		}
+	}
+
+	for _, i := range def.interfaces {
+		if err := c.collect(i); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range def.fields {
+		if err := c.collect(field.typeRef); err != nil {
+			return err
+		}
+
+		for _, arg := range field.args {
+			if err := c.collect(arg.typeRef); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, field := range def.inputFields {
+		if err := c.collect(field.typeRef); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildType returns the introspection type of the given type definition.
+func (b *schemaBuilder) buildType(def *typeDefinition) (types.IntrospectionType, error) {
+	if err := b.checkDirectives(def.directives, types.DirectiveLocation(def.kind)); err != nil {
+		return nil, err
+	}
+
+	switch def.kind {
+	case types.ScalarTypeKind:
+		url, err := specifiedByURL(def.directives)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.IntrospectionScalarType{
+			Kind:           def.kind,
+			Name:           def.name,
+			Description:    def.description,
+			SpecifiedByURL: url,
+		}, nil
+	case types.ObjectTypeKind, types.InterfaceTypeKind:
+		interfaces, err := b.namedTypeRefs(def.interfaces, types.InterfaceTypeKind)
+		if err != nil {
+			return nil, err
+		}
+
+		fields, err := b.buildFields(def)
+		if err != nil {
+			return nil, err
+		}
+
+		if def.kind == types.ObjectTypeKind {
+			return &types.IntrospectionObjectType{
+				Kind:        def.kind,
+				Name:        def.name,
+				Description: def.description,
+				Fields:      fields,
+				Interfaces:  interfaces,
+			}, nil
+		}
+
+		return &types.IntrospectionInterfaceType{
+			Kind:          def.kind,
+			Name:          def.name,
+			Description:   def.description,
+			Fields:        fields,
+			Interfaces:    interfaces,
+			PossibleTypes: []types.IntrospectionNamedTypeRef{},
+		}, nil
+	case types.UnionTypeKind:
+		members, err := b.namedTypeRefs(def.members, types.ObjectTypeKind)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.IntrospectionUnionType{
+			Kind:          def.kind,
+			Name:          def.name,
+			Description:   def.description,
+			PossibleTypes: members,
+		}, nil
+	case types.EnumTypeKind:
+		values, err := b.buildEnumValues(def)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.IntrospectionEnumType{
+			Kind:        def.kind,
+			Name:        def.name,
+			Description: def.description,
+			EnumValues:  values,
+		}, nil
+	default:
+		inputFields, err := b.buildInputValues(def.inputFields, types.InputFieldDefinition, "input field")
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.IntrospectionInputObjectType{
+			Kind:        def.kind,
+			Name:        def.name,
+			Description: def.description,
+			InputFields: inputFields,
+			IsOneOf:     hasDirective(def.directives, "oneOf"),
+		}, nil
+	}
+}
+
+// buildFields returns the introspection fields of the given object or interface type definition.
+func (b *schemaBuilder) buildFields(def *typeDefinition) ([]types.IntrospectionField, error) {
+	fields := []types.IntrospectionField{}
+	names := map[string]bool{}
+
+	for _, field := range def.fields {
+		if names[field.name] {
+			return nil, newSchemaError(field.location, "field \"%s.%s\" can only be defined once", def.name, field.name)
+		}
+
+		names[field.name] = true
+
+		if err := b.checkDirectives(field.directives, types.FieldDefinition); err != nil {
+			return nil, err
+		}
+
+		ref, err := b.typeRef(field.typeRef)
+		if err != nil {
+			return nil, err
+		}
+
+		if ref.NamedType().Kind == types.InputObjectTypeKind {
+			return nil, newSchemaError(field.typeRef.location, "field \"%s.%s\" must be an output type", def.name, field.name)
+		}
+
+		args, err := b.buildInputValues(field.args, types.ArgumentDefinition, "argument")
+		if err != nil {
+			return nil, err
+		}
+
+		reason, err := deprecationReason(field.directives)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, types.IntrospectionField{
+			Name:              field.name,
+			Description:       field.description,
+			Args:              args,
+			Type:              ref,
+			IsDeprecated:      reason != nil,
+			DeprecationReason: reason,
+		})
+	}
+
+	return fields, nil
+}
+
+// buildInputValues returns the introspection input values of the given argument or input field definitions.
+func (b *schemaBuilder) buildInputValues(
+	defs []*inputValueDefinition, location types.DirectiveLocation, subject string,
+) ([]types.IntrospectionInputValue, error) {
+	values := []types.IntrospectionInputValue{}
+	names := map[string]bool{}
+
+	for _, def := range defs {
+		if names[def.name] {
+			return nil, newSchemaError(def.location, "there can be only one %s named %q", subject, def.name)
+		}
+
+		names[def.name] = true
+
+		if err := b.checkDirectives(def.directives, location); err != nil {
+			return nil, err
+		}
+
+		ref, err := b.typeRef(def.typeRef)
+		if err != nil {
+			return nil, err
+		}
+
+		switch ref.NamedType().Kind {
+		case types.ScalarTypeKind, types.EnumTypeKind, types.InputObjectTypeKind:
+		default:
+			return nil, newSchemaError(def.typeRef.location, "%s %q must be an input type", subject, def.name)
+		}
+
+		reason, err := deprecationReason(def.directives)
+		if err != nil {
+			return nil, err
+		}
+
+		value := types.IntrospectionInputValue{
+			Name:              def.name,
+			Description:       def.description,
+			Type:              ref,
+			IsDeprecated:      reason != nil,
+			DeprecationReason: reason,
+		}
+
+		if def.defaultValue != nil {
+			defaultValue := def.defaultValue.String()
+			value.DefaultValue = &defaultValue
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// buildEnumValues returns the introspection enum values of the given enum type definition.
+func (b *schemaBuilder) buildEnumValues(def *typeDefinition) ([]types.IntrospectionEnumValue, error) {
+	values := []types.IntrospectionEnumValue{}
+	names := map[string]bool{}
+
+	for _, value := range def.values {
+		if names[value.name] {
+			return nil, newSchemaError(value.location, "enum value \"%s.%s\" can only be defined once", def.name, value.name)
+		}
+
+		names[value.name] = true
+
+		if err := b.checkDirectives(value.directives, types.EnumValue); err != nil {
+			return nil, err
+		}
+
+		reason, err := deprecationReason(value.directives)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, types.IntrospectionEnumValue{
+			Name:              value.name,
+			Description:       value.description,
+			IsDeprecated:      reason != nil,
+			DeprecationReason: reason,
+		})
+	}
+
+	return values, nil
+}
+
+// buildDirective returns the introspection directive of the given directive definition.
+func (b *schemaBuilder) buildDirective(def *directiveDefinition) (*types.IntrospectionDirective, error) {
+	if strings.HasPrefix(def.name, "__") {
+		return nil, newSchemaError(def.location, "name %q must not begin with \"__\"", def.name)
+	}
+
+	args, err := b.buildInputValues(def.args, types.ArgumentDefinition, "argument")
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.IntrospectionDirective{
+		Name:         def.name,
+		Description:  def.description,
+		IsRepeatable: def.repeatable,
+		Locations:    def.locations,
+		Args:         args,
+	}, nil
+}
+
+// addPossibleTypes sets the possible types of the given interface types, the object types implementing them
+// in schema order.
+func (b *schemaBuilder) addPossibleTypes(schemaTypes types.IntrospectionTypes) {
+	interfaces := map[string]*types.IntrospectionInterfaceType{}
+
+	for _, t := range schemaTypes {
+		if i, ok := t.(*types.IntrospectionInterfaceType); ok {
+			interfaces[i.Name] = i
+		}
+	}
+
+	for _, t := range schemaTypes {
+		object, ok := t.(*types.IntrospectionObjectType)
+		if !ok {
+			continue
+		}
+
+		for _, ref := range object.Interfaces {
+			if i, ok := interfaces[ref.Name]; ok {
+				i.PossibleTypes = append(i.PossibleTypes, *types.NewNamedTypeRef(object.Kind, object.Name))
+			}
+		}
+	}
+}
+
+// typeRef returns the introspection type reference of the given type node.
+func (b *schemaBuilder) typeRef(node *typeNode) (types.IntrospectionTypeRef, error) {
+	switch node.kind {
+	case types.ListTypeKind:
+		ofType, err := b.typeRef(node.ofType)
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewListTypeRef(ofType), nil
+	case types.NonNullTypeKind:
+		ofType, err := b.typeRef(node.ofType)
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewNonNullTypeRef(ofType), nil
+	default:
+		def, ok := b.lookup(node.name)
+		if !ok {
+			return nil, newSchemaError(node.location, "unknown type %q", node.name)
+		}
+
+		return types.NewNamedTypeRef(def.kind, def.name), nil
+	}
+}
+
+// namedTypeRefs returns the named type references of the given type nodes, which must be of the given kind.
+func (b *schemaBuilder) namedTypeRefs(nodes []*typeNode, kind types.TypeKind) ([]types.IntrospectionNamedTypeRef, error) {
+	refs := []types.IntrospectionNamedTypeRef{}
+	names := map[string]bool{}
+
+	for _, node := range nodes {
+		def, ok := b.lookup(node.name)
+		if !ok {
+			return nil, newSchemaError(node.location, "unknown type %q", node.name)
+		}
+
+		if def.kind != kind {
+			return nil, newSchemaError(node.location, "type %q must be of kind %s", node.name, kind)
+		}
+
+		if names[node.name] {
+			return nil, newSchemaError(node.location, "type %q can only be included once", node.name)
+		}
+
+		names[node.name] = true
+		refs = append(refs, *types.NewNamedTypeRef(def.kind, def.name))
+	}
+
+	return refs, nil
+}
+
+// checkDirectives checks that the given directives are defined, are used at the given location and that
+// the non-repeatable directives are used once.
+func (b *schemaBuilder) checkDirectives(directives []*directiveNode, location types.DirectiveLocation) error {
+	used := map[string]bool{}
+
+	for _, d := range directives {
+		def := b.directive(d.name)
+		if def == nil {
+			return newSchemaError(d.location, "unknown directive \"@%s\"", d.name)
+		}
+
+		allowed := false
+		for _, l := range def.locations {
+			allowed = allowed || l == location
+		}
+
+		if !allowed {
+			return newSchemaError(d.location, "directive \"@%s\" may not be used on %s", d.name, location)
+		}
+
+		if used[d.name] && !def.repeatable {
+			return newSchemaError(d.location, "the directive \"@%s\" can only be used once at this location", d.name)
+		}
+
+		used[d.name] = true
+	}
+
+	return nil
+}
+
+// directive returns the directive definition of the given name, nil when it is not defined.
+func (b *schemaBuilder) directive(name string) *directiveDefinition {
+	for _, def := range b.directives {
+		if def.name == name {
+			return def
+		}
+	}
+
+	return nil
+}
+
+// hasDirective returns whether the given directives contain the directive of the given name.
+func hasDirective(directives []*directiveNode, name string) bool {
+	for _, d := range directives {
+		if d.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deprecationReason returns the reason of the `@deprecated` directive of the given directives,
+// nil when the element is not deprecated.
+func deprecationReason(directives []*directiveNode) (*string, error) {
+	for _, d := range directives {
+		if d.name != "deprecated" {
+			continue
+		}
+
+		reason := defaultDeprecationReason
+
+		value, ok := d.args["reason"]
+		if !ok {
+			return &reason, nil
+		}
+
+		switch value.kind {
+		case nullValueKind:
+			return nil, nil
+		case stringValueKind:
+			reason = value.value
+			return &reason, nil
+		default:
+			return nil, newSchemaError(d.location, "argument \"@deprecated(reason:)\" must be a String")
+		}
+	}
+
+	return nil, nil
+}
+
+// specifiedByURL returns the URL of the `@specifiedBy` directive of the given directives, nil when it is not used.
+func specifiedByURL(directives []*directiveNode) (*string, error) {
+	for _, d := range directives {
+		if d.name != "specifiedBy" {
+			continue
+		}
+
+		value, ok := d.args["url"]
+		if !ok || value.kind != stringValueKind {
+			return nil, newSchemaError(d.location, "argument \"@specifiedBy(url:)\" must be a String")
+		}
+
+		url := value.value
+
+		return &url, nil
+	}
+
+	return nil, nil
+}
+
+// normalizeDefaultValues coerces the default values of the given schema to their types and prints them back,
+// as graphql-js introspection does, the invalid default values are removed.
+func normalizeDefaultValues(schema *types.IntrospectionSchema) {
+	namedTypes := map[string]types.IntrospectionType{}
+	for _, t := range schema.Types {
+		namedTypes[t.TypeName()] = t
+	}
+
+	c := newValueCoercer(namedTypes)
+
+	normalize := func(values []types.IntrospectionInputValue) {
+		for i := range values {
+			defaultValue, ok := c.printDefaultValue(&values[i])
+			if !ok {
+				values[i].DefaultValue = nil
+				continue
+			}
+
+			values[i].DefaultValue = &defaultValue
+		}
+	}
+
+	for _, t := range schema.Types {
+		switch t := t.(type) {
+		case *types.IntrospectionObjectType:
+			for i := range t.Fields {
+				normalize(t.Fields[i].Args)
+			}
+		case *types.IntrospectionInterfaceType:
+			for i := range t.Fields {
+				normalize(t.Fields[i].Args)
+			}
+		case *types.IntrospectionInputObjectType:
+			normalize(t.InputFields)
+		}
+	}
+
+	for i := range schema.Directives {
+		normalize(schema.Directives[i].Args)
+	}
+}
@@ -0,0 +1,664 @@
+// package sdl prints and parses the graphql schema definition language.
+package sdl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind is the kind of a lexical token of the graphql source.
+type tokenKind uint
+
+const (
+	// eofTokenKind is the kind of the end of the source.
+	eofTokenKind tokenKind = iota + 1
+
+	// bangTokenKind is the kind of the `!` punctuator.
+	bangTokenKind
+
+	// dollarTokenKind is the kind of the `$` punctuator.
+	dollarTokenKind
+
+	// ampTokenKind is the kind of the `&` punctuator.
+	ampTokenKind
+
+	// parenLTokenKind is the kind of the `(` punctuator.
+	parenLTokenKind
+
+	// parenRTokenKind is the kind of the `)` punctuator.
+	parenRTokenKind
+
+	// spreadTokenKind is the kind of the `...` punctuator.
+	spreadTokenKind
+
+	// colonTokenKind is the kind of the `:` punctuator.
+	colonTokenKind
+
+	// equalsTokenKind is the kind of the `=` punctuator.
+	equalsTokenKind
+
+	// atTokenKind is the kind of the `@` punctuator.
+	atTokenKind
+
+	// bracketLTokenKind is the kind of the `[` punctuator.
+	bracketLTokenKind
+
+	// bracketRTokenKind is the kind of the `]` punctuator.
+	bracketRTokenKind
+
+	// braceLTokenKind is the kind of the `{` punctuator.
+	braceLTokenKind
+
+	// pipeTokenKind is the kind of the `|` punctuator.
+	pipeTokenKind
+
+	// braceRTokenKind is the kind of the `}` punctuator.
+	braceRTokenKind
+
+	// nameTokenKind is the kind of the names.
+	nameTokenKind
+
+	// intTokenKind is the kind of the integer values.
+	intTokenKind
+
+	// floatTokenKind is the kind of the float values.
+	floatTokenKind
+
+	// stringTokenKind is the kind of the quoted string values.
+	stringTokenKind
+
+	// blockStringTokenKind is the kind of the triple quoted block string values.
+	blockStringTokenKind
+)
+
+// String returns the string representation of the token kind.
+func (k tokenKind) String() string {
+	switch k {
+	case eofTokenKind:
+		return "<EOF>"
+	case bangTokenKind:
+		return "!"
+	case dollarTokenKind:
+		return "$"
+	case ampTokenKind:
+		return "&"
+	case parenLTokenKind:
+		return "("
+	case parenRTokenKind:
+		return ")"
+	case spreadTokenKind:
+		return "..."
+	case colonTokenKind:
+		return ":"
+	case equalsTokenKind:
+		return "="
+	case atTokenKind:
+		return "@"
+	case bracketLTokenKind:
+		return "["
+	case bracketRTokenKind:
+		return "]"
+	case braceLTokenKind:
+		return "{"
+	case pipeTokenKind:
+		return "|"
+	case braceRTokenKind:
+		return "}"
+	case nameTokenKind:
+		return "Name"
+	case intTokenKind:
+		return "Int"
+	case floatTokenKind:
+		return "Float"
+	case stringTokenKind:
+		return "String"
+	case blockStringTokenKind:
+		return "BlockString"
+	default:
+		return "unknown"
+	}
+}
+
+// punctuators are the token kinds of the single character punctuators.
+var punctuators = map[rune]tokenKind{
+	'!': bangTokenKind,
+	'$': dollarTokenKind,
+	'&': ampTokenKind,
+	'(': parenLTokenKind,
+	')': parenRTokenKind,
+	':': colonTokenKind,
+	'=': equalsTokenKind,
+	'@': atTokenKind,
+	'[': bracketLTokenKind,
+	']': bracketRTokenKind,
+	'{': braceLTokenKind,
+	'|': pipeTokenKind,
+	'}': braceRTokenKind,
+}
+
+// SyntaxError represents an error of the graphql source at a line and column, both starting at 1.
+type SyntaxError struct {
+	// Message is the error message.
+	Message string
+
+	// Line is the line of the error.
+	Line int
+
+	// Column is the column of the error.
+	Column int
+}
+
+// Error returns the error message with its location.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// token represents a lexical token of the graphql source.
+type token struct {
+	// kind is the token kind.
+	kind tokenKind
+
+	// value is the name, the number or the decoded string value of the token.
+	value string
+
+	// start is the byte offset of the token start.
+	start int
+
+	// line is the line of the token start.
+	line int
+
+	// column is the column of the token start.
+	column int
+}
+
+// String returns the description of the token used in the error messages.
+func (t *token) String() string {
+	switch t.kind {
+	case nameTokenKind, intTokenKind, floatTokenKind:
+		return fmt.Sprintf("%s %q", t.kind, t.value)
+	case eofTokenKind, stringTokenKind, blockStringTokenKind:
+		return t.kind.String()
+	default:
+		return fmt.Sprintf("%q", t.kind.String())
+	}
+}
+
+// lexer represents the lexer of a graphql source, comments, commas and white spaces are ignored.
+type lexer struct {
+	// source is the graphql source.
+	source string
+
+	// pos is the byte offset of the next character.
+	pos int
+
+	// line is the line of the next character.
+	line int
+
+	// lineStart is the byte offset of the current line start.
+	lineStart int
+
+	// token is the current token.
+	token *token
+}
+
+// newLexer returns a pointer to a lexer of the given source, positioned before the first token.
+func newLexer(source string) *lexer {
+	source = strings.TrimPrefix(source, "\uFEFF")
+
+	return &lexer{source: source, line: 1}
+}
+
+// advance reads the next token into the current token.
+func (l *lexer) advance() error {
+	t, err := l.next()
+	if err != nil {
+		return err
+	}
+
+	l.token = t
+
+	return nil
+}
+
+// errorAt returns the syntax error of the given message at the given byte offset.
+func (l *lexer) errorAt(pos int, message string) *SyntaxError {
+	line, column := l.location(pos)
+
+	return &SyntaxError{Message: message, Line: line, Column: column}
+}
+
+// location returns the line and column of the given byte offset, the column counts characters.
+func (l *lexer) location(pos int) (int, int) {
+	line := 1
+	lineStart := 0
+
+	for i := 0; i < pos && i < len(l.source); i++ {
+		switch l.source[i] {
+		case '\n':
+			line++
+			lineStart = i + 1
+		case '\r':
+			if i+1 < len(l.source) && l.source[i+1] == '\n' {
+				continue
+			}
+
+			line++
+			lineStart = i + 1
+		}
+	}
+
+	return line, utf8.RuneCountInString(l.source[lineStart:pos]) + 1
+}
+
+// peek returns the character at the given byte offset and its size, -1 at the end of the source.
+func (l *lexer) peek(pos int) (rune, int) {
+	if pos >= len(l.source) {
+		return -1, 0
+	}
+
+	return utf8.DecodeRuneInString(l.source[pos:])
+}
+
+// newLine moves the current line to the given byte offset.
+func (l *lexer) newLine(pos int) {
+	l.line++
+	l.lineStart = pos
+}
+
+// newToken returns the token of the given kind and value starting at the given byte offset.
+func (l *lexer) newToken(kind tokenKind, value string, start int) *token {
+	return &token{
+		kind:   kind,
+		value:  value,
+		start:  start,
+		line:   l.line,
+		column: utf8.RuneCountInString(l.source[l.lineStart:start]) + 1,
+	}
+}
+
+// next reads the next token of the source.
+func (l *lexer) next() (*token, error) {
+	for {
+		c, size := l.peek(l.pos)
+
+		switch {
+		case c == -1:
+			return l.newToken(eofTokenKind, "", l.pos), nil
+		case c == ' ' || c == '\t' || c == ',' || c == '\uFEFF':
+			l.pos += size
+		case c == '\n':
+			l.pos += size
+			l.newLine(l.pos)
+		case c == '\r':
+			l.pos += size
+			if next, _ := l.peek(l.pos); next == '\n' {
+				l.pos++
+			}
+
+			l.newLine(l.pos)
+		case c == '#':
+			l.skipComment()
+		case c == '.':
+			if strings.HasPrefix(l.source[l.pos:], "...") {
+				t := l.newToken(spreadTokenKind, "", l.pos)
+				l.pos += 3
+
+				return t, nil
+			}
+
+			return nil, l.errorAt(l.pos, `unexpected character: "."`)
+		case punctuators[c] != 0:
+			t := l.newToken(punctuators[c], "", l.pos)
+			l.pos += size
+
+			return t, nil
+		case isNameStart(c):
+			return l.readName(), nil
+		case c == '-' || isDigit(c):
+			return l.readNumber()
+		case c == '"':
+			if strings.HasPrefix(l.source[l.pos:], `"""`) {
+				return l.readBlockString()
+			}
+
+			return l.readString()
+		default:
+			return nil, l.errorAt(l.pos, fmt.Sprintf("unexpected character: %s", printCharacter(c)))
+		}
+	}
+}
+
+// skipComment skips the comment starting at the current position, until the end of the line.
+func (l *lexer) skipComment() {
+	for {
+		c, size := l.peek(l.pos)
+		if c == -1 || c == '\n' || c == '\r' {
+			return
+		}
+
+		l.pos += size
+	}
+}
+
+// readName reads the name starting at the current position.
+func (l *lexer) readName() *token {
+	start := l.pos
+
+	for {
+		c, size := l.peek(l.pos)
+		if !isNameContinue(c) {
+			break
+		}
+
+		l.pos += size
+	}
+
+	return l.newToken(nameTokenKind, l.source[start:l.pos], start)
+}
+
+// readNumber reads the integer or float number starting at the current position.
+func (l *lexer) readNumber() (*token, error) {
+	start := l.pos
+	kind := intTokenKind
+
+	c, _ := l.peek(l.pos)
+	if c == '-' {
+		l.pos++
+		c, _ = l.peek(l.pos)
+	}
+
+	if c == '0' {
+		l.pos++
+		c, _ = l.peek(l.pos)
+
+		if isDigit(c) {
+			return nil, l.errorAt(l.pos, fmt.Sprintf("invalid number, unexpected digit after 0: %s", printCharacter(c)))
+		}
+	} else if err := l.readDigits(); err != nil {
+		return nil, err
+	}
+
+	c, _ = l.peek(l.pos)
+	if c == '.' {
+		kind = floatTokenKind
+		l.pos++
+
+		if err := l.readDigits(); err != nil {
+			return nil, err
+		}
+
+		c, _ = l.peek(l.pos)
+	}
+
+	if c == 'e' || c == 'E' {
+		kind = floatTokenKind
+		l.pos++
+
+		c, _ = l.peek(l.pos)
+		if c == '+' || c == '-' {
+			l.pos++
+		}
+
+		if err := l.readDigits(); err != nil {
+			return nil, err
+		}
+
+		c, _ = l.peek(l.pos)
+	}
+
+	if c == '.' || isNameStart(c) {
+		return nil, l.errorAt(l.pos, fmt.Sprintf("invalid number, expected digit but got: %s", printCharacter(c)))
+	}
+
+	return l.newToken(kind, l.source[start:l.pos], start), nil
+}
+
+// readDigits reads at least one digit starting at the current position.
+func (l *lexer) readDigits() error {
+	c, _ := l.peek(l.pos)
+	if !isDigit(c) {
+		return l.errorAt(l.pos, fmt.Sprintf("invalid number, expected digit but got: %s", printCharacter(c)))
+	}
+
+	for isDigit(c) {
+		l.pos++
+		c, _ = l.peek(l.pos)
+	}
+
+	return nil
+}
+
+// readString reads the quoted string starting at the current position and decodes its escape sequences.
+func (l *lexer) readString() (*token, error) {
+	start := l.pos
+	l.pos++
+
+	b := strings.Builder{}
+
+	for {
+		c, size := l.peek(l.pos)
+
+		switch {
+		case c == -1 || c == '\n' || c == '\r':
+			return nil, l.errorAt(l.pos, "unterminated string")
+		case c == '"':
+			l.pos++
+			return l.newToken(stringTokenKind, b.String(), start), nil
+		case c == '\\':
+			r, n, err := l.readEscape()
+			if err != nil {
+				return nil, err
+			}
+
+			b.WriteRune(r)
+			l.pos += n
+		case c < 0x20 && c != '\t':
+			return nil, l.errorAt(l.pos, fmt.Sprintf("invalid character within string: %s", printCharacter(c)))
+		default:
+			b.WriteRune(c)
+			l.pos += size
+		}
+	}
+}
+
+// readEscape decodes the escape sequence at the current position, returns the character and the sequence size.
+func (l *lexer) readEscape() (rune, int, error) {
+	c, _ := l.peek(l.pos + 1)
+
+	switch c {
+	case '"':
+		return '"', 2, nil
+	case '\\':
+		return '\\', 2, nil
+	case '/':
+		return '/', 2, nil
+	case 'b':
+		return '\b', 2, nil
+	case 'f':
+		return '\f', 2, nil
+	case 'n':
+		return '\n', 2, nil
+	case 'r':
+		return '\r', 2, nil
+	case 't':
+		return '\t', 2, nil
+	case 'u':
+		return l.readUnicodeEscape()
+	default:
+		end := l.pos + 2
+		if end > len(l.source) {
+			end = len(l.source)
+		}
+
+		return 0, 0, l.errorAt(l.pos, fmt.Sprintf("invalid character escape sequence: %q", l.source[l.pos:end]))
+	}
+}
+
+// readUnicodeEscape decodes the `\uXXXX`, the surrogate pairs and the `\u{X}` escape sequences.
+func (l *lexer) readUnicodeEscape() (rune, int, error) {
+	rest := l.source[l.pos:]
+
+	if strings.HasPrefix(rest, `\u{`) {
+		end := strings.IndexByte(rest, '}')
+		if end > 3 && end <= 9 {
+			if code, err := strconv.ParseUint(rest[3:end], 16, 32); err == nil && utf8.ValidRune(rune(code)) {
+				return rune(code), end + 1, nil
+			}
+		}
+	} else if len(rest) >= 6 {
+		if code, err := strconv.ParseUint(rest[2:6], 16, 32); err == nil {
+			r := rune(code)
+
+			if r < 0xD800 || r > 0xDFFF {
+				return r, 6, nil
+			}
+
+			if r <= 0xDBFF && len(rest) >= 12 && rest[6:8] == `\u` {
+				if low, err := strconv.ParseUint(rest[8:12], 16, 32); err == nil && low >= 0xDC00 && low <= 0xDFFF {
+					return (r-0xD800)<<10 + (rune(low) - 0xDC00) + 0x10000, 12, nil
+				}
+			}
+		}
+	}
+
+	end := l.pos + 6
+	if end > len(l.source) {
+		end = len(l.source)
+	}
+
+	return 0, 0, l.errorAt(l.pos, fmt.Sprintf("invalid unicode escape sequence: %q", l.source[l.pos:end]))
+}
+
+// readBlockString reads the block string starting at the current position and returns its dedented value.
+func (l *lexer) readBlockString() (*token, error) {
+	start := l.pos
+	line, lineStart := l.line, l.lineStart
+	l.pos += 3
+
+	raw := strings.Builder{}
+
+	for {
+		c, size := l.peek(l.pos)
+
+		switch {
+		case c == -1:
+			return nil, l.errorAt(l.pos, "unterminated string")
+		case strings.HasPrefix(l.source[l.pos:], `"""`):
+			l.pos += 3
+
+			t := &token{
+				kind:   blockStringTokenKind,
+				value:  blockStringValue(raw.String()),
+				start:  start,
+				line:   line,
+				column: utf8.RuneCountInString(l.source[lineStart:start]) + 1,
+			}
+
+			return t, nil
+		case strings.HasPrefix(l.source[l.pos:], `\"""`):
+			raw.WriteString(`"""`)
+			l.pos += 4
+		case c == '\n':
+			raw.WriteRune('\n')
+			l.pos++
+			l.newLine(l.pos)
+		case c == '\r':
+			raw.WriteRune('\n')
+			l.pos++
+
+			if next, _ := l.peek(l.pos); next == '\n' {
+				l.pos++
+			}
+
+			l.newLine(l.pos)
+		case c < 0x20 && c != '\t':
+			return nil, l.errorAt(l.pos, fmt.Sprintf("invalid character within string: %s", printCharacter(c)))
+		default:
+			raw.WriteRune(c)
+			l.pos += size
+		}
+	}
+}
+
+// blockStringValue returns the value of the given raw block string, with its common indentation
+// and its leading and trailing blank lines removed.
+func blockStringValue(raw string) string {
+	lines := strings.Split(raw, "\n")
+
+	commonIndent := -1
+	firstNonEmpty := -1
+	lastNonEmpty := -1
+
+	for i, line := range lines {
+		indent := leadingWhiteSpace(line)
+		if indent == len(line) {
+			continue
+		}
+
+		if firstNonEmpty == -1 {
+			firstNonEmpty = i
+		}
+
+		lastNonEmpty = i
+
+		if i != 0 && (commonIndent == -1 || indent < commonIndent) {
+			commonIndent = indent
+		}
+	}
+
+	if firstNonEmpty == -1 {
+		return ""
+	}
+
+	for i := range lines {
+		if i != 0 && commonIndent > 0 {
+			if len(lines[i]) < commonIndent {
+				lines[i] = ""
+			} else {
+				lines[i] = lines[i][commonIndent:]
+			}
+		}
+	}
+
+	return strings.Join(lines[firstNonEmpty:lastNonEmpty+1], "\n")
+}
+
+// leadingWhiteSpace returns the number of leading spaces and tabs of the given line.
+func leadingWhiteSpace(line string) int {
+	i := 0
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+
+	return i
+}
+
+// isNameStart returns whether the given character may start a name.
+func isNameStart(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isNameContinue returns whether the given character may continue a name.
+func isNameContinue(c rune) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+// isDigit returns whether the given character is a decimal digit.
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+// printCharacter returns the given character as printed in the error messages.
+func printCharacter(c rune) string {
+	if c == -1 {
+		return "<EOF>"
+	}
+
+	if c >= 0x20 && c <= 0x7E {
+		return strconv.Quote(string(c))
+	}
+
+	return fmt.Sprintf("U+%04X", c)
+}
@@ -0,0 +1,134 @@
+package sdl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer(t *testing.T) {
+	tests := []struct {
+		subTestName    string
+		source         string
+		expectedKinds  []tokenKind
+		expectedValues []string
+	}{
+		{
+			subTestName: "Handles punctuators",
+			source:      "! $ & ( ) ... : = @ [ ] { | }",
+			expectedKinds: []tokenKind{
+				bangTokenKind, dollarTokenKind, ampTokenKind, parenLTokenKind, parenRTokenKind, spreadTokenKind,
+				colonTokenKind, equalsTokenKind, atTokenKind, bracketLTokenKind, bracketRTokenKind, braceLTokenKind,
+				pipeTokenKind, braceRTokenKind,
+			},
+			expectedValues: []string{"", "", "", "", "", "", "", "", "", "", "", "", "", ""},
+		},
+		{
+			subTestName: "Handles names and numbers",
+			source:      "type _Query1, 0 -12 1.5 3e10 -0.1E-2 # comment",
+			expectedKinds: []tokenKind{
+				nameTokenKind, nameTokenKind, intTokenKind, intTokenKind, floatTokenKind, floatTokenKind, floatTokenKind,
+			},
+			expectedValues: []string{"type", "_Query1", "0", "-12", "1.5", "3e10", "-0.1E-2"},
+		},
+		{
+			subTestName:    "Handles strings",
+			source:         `"quote \" slash \\ \/ \n é \u{1F600} 😀"`,
+			expectedKinds:  []tokenKind{stringTokenKind},
+			expectedValues: []string{"quote \" slash \\ / \n é 😀 😀"},
+		},
+		{
+			subTestName:    "Handles block strings",
+			source:         "\"\"\"\n    first\n      second\n    \\\"\"\"\n\n  \"\"\"",
+			expectedKinds:  []tokenKind{blockStringTokenKind},
+			expectedValues: []string{"first\n  second\n\"\"\""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			l := newLexer(tt.source)
+
+			kinds := []tokenKind{}
+			values := []string{}
+
+			for {
+				if err := l.advance(); err != nil {
+					t.Fatalf("failed to read a token: %v", err)
+				}
+
+				if l.token.kind == eofTokenKind {
+					break
+				}
+
+				kinds = append(kinds, l.token.kind)
+				values = append(values, l.token.value)
+			}
+
+			assert.Equal(t, tt.expectedKinds, kinds)
+			assert.Equal(t, tt.expectedValues, values)
+		})
+	}
+}
+
+func TestLexerErrors(t *testing.T) {
+	tests := []struct {
+		subTestName    string
+		source         string
+		expectedLine   int
+		expectedColumn int
+	}{
+		{
+			subTestName:    "Handles unexpected character",
+			source:         "type Query {\n  field: ?\n}",
+			expectedLine:   2,
+			expectedColumn: 10,
+		},
+		{
+			subTestName:    "Handles unterminated string",
+			source:         `"open`,
+			expectedLine:   1,
+			expectedColumn: 6,
+		},
+		{
+			subTestName:    "Handles leading zero",
+			source:         "\r\n  01",
+			expectedLine:   2,
+			expectedColumn: 4,
+		},
+		{
+			subTestName:    "Handles invalid escape",
+			source:         `"\x"`,
+			expectedLine:   1,
+			expectedColumn: 2,
+		},
+		{
+			subTestName:    "Handles unterminated block string",
+			source:         "\"\"\"\nopen",
+			expectedLine:   2,
+			expectedColumn: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			l := newLexer(tt.source)
+
+			var err error
+			for err == nil {
+				err = l.advance()
+				if err == nil && l.token.kind == eofTokenKind {
+					t.Fatalf("expected: error, got: %v", l.token)
+				}
+			}
+
+			syntaxErr, ok := err.(*SyntaxError)
+			if !ok {
+				t.Fatalf("expected: *SyntaxError, got: %T", err)
+			}
+
+			assert.Equal(t, tt.expectedLine, syntaxErr.Line)
+			assert.Equal(t, tt.expectedColumn, syntaxErr.Column)
+		})
+	}
+}
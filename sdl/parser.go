@@ -0,0 +1,934 @@
+package sdl
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// directiveLocations are the valid directive locations.
+var directiveLocations = map[string]types.DirectiveLocation{
+	string(types.Query):                types.Query,
+	string(types.Mutation):             types.Mutation,
+	string(types.Subscription):         types.Subscription,
+	string(types.Field):                types.Field,
+	string(types.FragmentDefinition):   types.FragmentDefinition,
+	string(types.FragmentSpread):       types.FragmentSpread,
+	string(types.InlineFragment):       types.InlineFragment,
+	string(types.VariableDefinition):   types.VariableDefinition,
+	string(types.Schema):               types.Schema,
+	string(types.Scalar):               types.Scalar,
+	string(types.Object):               types.Object,
+	string(types.FieldDefinition):      types.FieldDefinition,
+	string(types.ArgumentDefinition):   types.ArgumentDefinition,
+	string(types.Interface):            types.Interface,
+	string(types.Union):                types.Union,
+	string(types.Enum):                 types.Enum,
+	string(types.EnumValue):            types.EnumValue,
+	string(types.InputObject):          types.InputObject,
+	string(types.InputFieldDefinition): types.InputFieldDefinition,
+}
+
+// location represents the line and column of a node of the graphql source.
+type location struct {
+	// line is the line of the node.
+	line int
+
+	// column is the column of the node.
+	column int
+}
+
+// document represents the type system definitions and extensions of a graphql source.
+type document struct {
+	// schemas are the schema definition and extensions.
+	schemas []*schemaDefinition
+
+	// types are the type definitions and extensions.
+	types []*typeDefinition
+
+	// directives are the directive definitions.
+	directives []*directiveDefinition
+}
+
+// schemaDefinition represents a schema definition or extension.
+type schemaDefinition struct {
+	location
+
+	// description is the schema description.
+	description *string
+
+	// operationTypes are the root operation types, keyed by operation.
+	operationTypes []*operationTypeDefinition
+
+	// extension is whether the definition is an extension.
+	extension bool
+}
+
+// operationTypeDefinition represents a root operation type of a schema definition.
+type operationTypeDefinition struct {
+	// operation is the operation, `query`, `mutation` or `subscription`.
+	operation string
+
+	// typeName is the root operation type.
+	typeName *typeNode
+}
+
+// typeDefinition represents a named type definition or extension.
+type typeDefinition struct {
+	location
+
+	// kind is the type kind.
+	kind types.TypeKind
+
+	// name is the type name.
+	name string
+
+	// description is the type description.
+	description *string
+
+	// directives are the directives of the type.
+	directives []*directiveNode
+
+	// interfaces are the implemented interfaces of the object and interface types.
+	interfaces []*typeNode
+
+	// fields are the fields of the object and interface types.
+	fields []*fieldDefinition
+
+	// inputFields are the input fields of the input object types.
+	inputFields []*inputValueDefinition
+
+	// values are the values of the enum types.
+	values []*enumValueDefinition
+
+	// members are the member types of the union types.
+	members []*typeNode
+
+	// extension is whether the definition is an extension.
+	extension bool
+}
+
+// fieldDefinition represents a field definition.
+type fieldDefinition struct {
+	location
+
+	// description is the field description.
+	description *string
+
+	// name is the field name.
+	name string
+
+	// args are the field arguments.
+	args []*inputValueDefinition
+
+	// typeRef is the field type.
+	typeRef *typeNode
+
+	// directives are the directives of the field.
+	directives []*directiveNode
+}
+
+// inputValueDefinition represents an argument or input field definition.
+type inputValueDefinition struct {
+	location
+
+	// description is the input value description.
+	description *string
+
+	// name is the input value name.
+	name string
+
+	// typeRef is the input value type.
+	typeRef *typeNode
+
+	// defaultValue is the default value, nil when it has no default value.
+	defaultValue *valueNode
+
+	// directives are the directives of the input value.
+	directives []*directiveNode
+}
+
+// enumValueDefinition represents an enum value definition.
+type enumValueDefinition struct {
+	location
+
+	// description is the enum value description.
+	description *string
+
+	// name is the enum value name.
+	name string
+
+	// directives are the directives of the enum value.
+	directives []*directiveNode
+}
+
+// directiveDefinition represents a directive definition.
+type directiveDefinition struct {
+	location
+
+	// description is the directive description.
+	description *string
+
+	// name is the directive name.
+	name string
+
+	// args are the directive arguments.
+	args []*inputValueDefinition
+
+	// repeatable is whether the directive may be used more than once at the same location.
+	repeatable bool
+
+	// locations are the locations where the directive may be used.
+	locations []types.DirectiveLocation
+}
+
+// directiveNode represents a directive used on a definition.
+type directiveNode struct {
+	location
+
+	// name is the directive name.
+	name string
+
+	// args are the directive arguments, keyed by name.
+	args map[string]*valueNode
+}
+
+// typeNode represents a type reference, a named type or a list or non-null wrapper.
+type typeNode struct {
+	location
+
+	// name is the name of the named types.
+	name string
+
+	// kind is the wrapper kind, `LIST` or `NON_NULL`, empty for the named types.
+	kind types.TypeKind
+
+	// ofType is the wrapped type.
+	ofType *typeNode
+}
+
+// parser represents the parser of the type system documents.
+type parser struct {
+	// lexer is the lexer of the graphql source.
+	lexer *lexer
+}
+
+// parseDocument parses the given type system document source, which must have at least one definition.
+func parseDocument(source string) (*document, error) {
+	p := &parser{lexer: newLexer(source)}
+	if err := p.lexer.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.peek(eofTokenKind) {
+		return nil, p.unexpected()
+	}
+
+	doc := &document{}
+
+	for !p.peek(eofTokenKind) {
+		if err := p.parseDefinition(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// parseDefinition parses the definition or extension at the current token into the given document.
+func (p *parser) parseDefinition(doc *document) error {
+	description, err := p.parseDescription()
+	if err != nil {
+		return err
+	}
+
+	t := p.lexer.token
+	if t.kind == braceLTokenKind {
+		return p.errorAt(t, "unexpected executable definition")
+	}
+
+	if t.kind != nameTokenKind {
+		return p.unexpected()
+	}
+
+	switch t.value {
+	case "schema":
+		def, err := p.parseSchemaDefinition(description)
+		if err != nil {
+			return err
+		}
+
+		doc.schemas = append(doc.schemas, def)
+	case "scalar", "type", "interface", "union", "enum", "input":
+		def, err := p.parseTypeDefinition(description, false)
+		if err != nil {
+			return err
+		}
+
+		doc.types = append(doc.types, def)
+	case "directive":
+		def, err := p.parseDirectiveDefinition(description)
+		if err != nil {
+			return err
+		}
+
+		doc.directives = append(doc.directives, def)
+	case "extend":
+		if description != nil {
+			return p.errorAt(t, "unexpected description on an extension")
+		}
+
+		return p.parseExtension(doc)
+	case "query", "mutation", "subscription", "fragment":
+		return p.errorAt(t, "unexpected executable definition")
+	default:
+		return p.unexpected()
+	}
+
+	return nil
+}
+
+// parseExtension parses the schema or type extension at the current token into the given document.
+func (p *parser) parseExtension(doc *document) error {
+	l := p.location()
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	t := p.lexer.token
+	if t.kind != nameTokenKind {
+		return p.unexpected()
+	}
+
+	switch t.value {
+	case "schema":
+		def, err := p.parseSchemaDefinition(nil)
+		if err != nil {
+			return err
+		}
+
+		def.location = l
+		def.extension = true
+		doc.schemas = append(doc.schemas, def)
+	case "scalar", "type", "interface", "union", "enum", "input":
+		def, err := p.parseTypeDefinition(nil, true)
+		if err != nil {
+			return err
+		}
+
+		def.location = l
+		doc.types = append(doc.types, def)
+	default:
+		return p.unexpected()
+	}
+
+	return nil
+}
+
+// parseSchemaDefinition parses the schema definition or extension at the current `schema` keyword.
+func (p *parser) parseSchemaDefinition(description *string) (*schemaDefinition, error) {
+	def := &schemaDefinition{location: p.location(), description: description}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if !p.peek(braceLTokenKind) {
+		return def, nil
+	}
+
+	err := p.many(braceLTokenKind, braceRTokenKind, func() error {
+		operation, err := p.expectName()
+		if err != nil {
+			return err
+		}
+
+		if operation.value != "query" && operation.value != "mutation" && operation.value != "subscription" {
+			return p.errorAt(operation, fmt.Sprintf("unexpected operation: %q", operation.value))
+		}
+
+		if err := p.expect(colonTokenKind); err != nil {
+			return err
+		}
+
+		typeName, err := p.parseNamedType()
+		if err != nil {
+			return err
+		}
+
+		def.operationTypes = append(def.operationTypes, &operationTypeDefinition{
+			operation: operation.value,
+			typeName:  typeName,
+		})
+
+		return nil
+	})
+
+	return def, err
+}
+
+// parseTypeDefinition parses the type definition or extension at the current type keyword.
+func (p *parser) parseTypeDefinition(description *string, extension bool) (*typeDefinition, error) {
+	keyword := p.lexer.token.value
+
+	def := &typeDefinition{location: p.location(), description: description, extension: extension}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	def.name = name.value
+
+	switch keyword {
+	case "scalar":
+		def.kind = types.ScalarTypeKind
+		def.directives, err = p.parseDirectives()
+	case "type", "interface":
+		def.kind = types.ObjectTypeKind
+		if keyword == "interface" {
+			def.kind = types.InterfaceTypeKind
+		}
+
+		err = p.parseObjectDefinition(def)
+	case "union":
+		def.kind = types.UnionTypeKind
+		err = p.parseUnionDefinition(def)
+	case "enum":
+		def.kind = types.EnumTypeKind
+		err = p.parseEnumDefinition(def)
+	case "input":
+		def.kind = types.InputObjectTypeKind
+		err = p.parseInputObjectDefinition(def)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+// parseObjectDefinition parses the implemented interfaces, the directives and the fields of the given
+// object or interface type definition.
+func (p *parser) parseObjectDefinition(def *typeDefinition) error {
+	if p.peekKeyword("implements") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+
+		if err := p.skip(ampTokenKind); err != nil {
+			return err
+		}
+
+		for {
+			i, err := p.parseNamedType()
+			if err != nil {
+				return err
+			}
+
+			def.interfaces = append(def.interfaces, i)
+
+			if !p.peek(ampTokenKind) {
+				break
+			}
+
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+	}
+
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return err
+	}
+
+	def.directives = directives
+
+	if !p.peek(braceLTokenKind) {
+		return nil
+	}
+
+	return p.many(braceLTokenKind, braceRTokenKind, func() error {
+		field, err := p.parseFieldDefinition()
+		if err != nil {
+			return err
+		}
+
+		def.fields = append(def.fields, field)
+
+		return nil
+	})
+}
+
+// parseUnionDefinition parses the directives and the member types of the given union type definition.
+func (p *parser) parseUnionDefinition(def *typeDefinition) error {
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return err
+	}
+
+	def.directives = directives
+
+	if !p.peek(equalsTokenKind) {
+		return nil
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	if err := p.skip(pipeTokenKind); err != nil {
+		return err
+	}
+
+	for {
+		member, err := p.parseNamedType()
+		if err != nil {
+			return err
+		}
+
+		def.members = append(def.members, member)
+
+		if !p.peek(pipeTokenKind) {
+			return nil
+		}
+
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+}
+
+// parseEnumDefinition parses the directives and the values of the given enum type definition.
+func (p *parser) parseEnumDefinition(def *typeDefinition) error {
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return err
+	}
+
+	def.directives = directives
+
+	if !p.peek(braceLTokenKind) {
+		return nil
+	}
+
+	return p.many(braceLTokenKind, braceRTokenKind, func() error {
+		description, err := p.parseDescription()
+		if err != nil {
+			return err
+		}
+
+		value := &enumValueDefinition{location: p.location(), description: description}
+
+		name, err := p.expectName()
+		if err != nil {
+			return err
+		}
+
+		if name.value == "true" || name.value == "false" || name.value == "null" {
+			return p.errorAt(name, fmt.Sprintf("unexpected enum value name: %q", name.value))
+		}
+
+		value.name = name.value
+
+		if value.directives, err = p.parseDirectives(); err != nil {
+			return err
+		}
+
+		def.values = append(def.values, value)
+
+		return nil
+	})
+}
+
+// parseInputObjectDefinition parses the directives and the input fields of the given input object type definition.
+func (p *parser) parseInputObjectDefinition(def *typeDefinition) error {
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return err
+	}
+
+	def.directives = directives
+
+	if !p.peek(braceLTokenKind) {
+		return nil
+	}
+
+	return p.many(braceLTokenKind, braceRTokenKind, func() error {
+		field, err := p.parseInputValueDefinition()
+		if err != nil {
+			return err
+		}
+
+		def.inputFields = append(def.inputFields, field)
+
+		return nil
+	})
+}
+
+// parseFieldDefinition parses the field definition at the current token.
+func (p *parser) parseFieldDefinition() (*fieldDefinition, error) {
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+
+	field := &fieldDefinition{location: p.location(), description: description}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	field.name = name.value
+
+	if field.args, err = p.parseArgumentDefinitions(); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(colonTokenKind); err != nil {
+		return nil, err
+	}
+
+	if field.typeRef, err = p.parseType(); err != nil {
+		return nil, err
+	}
+
+	if field.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+// parseArgumentDefinitions parses the optional argument definitions at the current token.
+func (p *parser) parseArgumentDefinitions() ([]*inputValueDefinition, error) {
+	args := []*inputValueDefinition{}
+
+	if !p.peek(parenLTokenKind) {
+		return args, nil
+	}
+
+	err := p.many(parenLTokenKind, parenRTokenKind, func() error {
+		arg, err := p.parseInputValueDefinition()
+		if err != nil {
+			return err
+		}
+
+		args = append(args, arg)
+
+		return nil
+	})
+
+	return args, err
+}
+
+// parseInputValueDefinition parses the argument or input field definition at the current token.
+func (p *parser) parseInputValueDefinition() (*inputValueDefinition, error) {
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+
+	value := &inputValueDefinition{location: p.location(), description: description}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	value.name = name.value
+
+	if err := p.expect(colonTokenKind); err != nil {
+		return nil, err
+	}
+
+	if value.typeRef, err = p.parseType(); err != nil {
+		return nil, err
+	}
+
+	if p.peek(equalsTokenKind) {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if value.defaultValue, err = parseValueLiteral(p.lexer); err != nil {
+			return nil, err
+		}
+	}
+
+	if value.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// parseDirectiveDefinition parses the directive definition at the current `directive` keyword.
+func (p *parser) parseDirectiveDefinition(description *string) (*directiveDefinition, error) {
+	def := &directiveDefinition{location: p.location(), description: description}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(atTokenKind); err != nil {
+		return nil, err
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	def.name = name.value
+
+	if def.args, err = p.parseArgumentDefinitions(); err != nil {
+		return nil, err
+	}
+
+	if p.peekKeyword("repeatable") {
+		def.repeatable = true
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expectKeyword("on"); err != nil {
+		return nil, err
+	}
+
+	if err := p.skip(pipeTokenKind); err != nil {
+		return nil, err
+	}
+
+	for {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		l, ok := directiveLocations[name.value]
+		if !ok {
+			return nil, p.errorAt(name, fmt.Sprintf("unexpected directive location: %q", name.value))
+		}
+
+		def.locations = append(def.locations, l)
+
+		if !p.peek(pipeTokenKind) {
+			return def, nil
+		}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseDirectives parses the optional constant directives at the current token.
+func (p *parser) parseDirectives() ([]*directiveNode, error) {
+	directives := []*directiveNode{}
+
+	for p.peek(atTokenKind) {
+		directive := &directiveNode{location: p.location(), args: map[string]*valueNode{}}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		directive.name = name.value
+
+		if p.peek(parenLTokenKind) {
+			err := p.many(parenLTokenKind, parenRTokenKind, func() error {
+				arg, err := p.expectName()
+				if err != nil {
+					return err
+				}
+
+				if err := p.expect(colonTokenKind); err != nil {
+					return err
+				}
+
+				value, err := parseValueLiteral(p.lexer)
+				if err != nil {
+					return err
+				}
+
+				directive.args[arg.value] = value
+
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		directives = append(directives, directive)
+	}
+
+	return directives, nil
+}
+
+// parseType parses the type reference at the current token.
+func (p *parser) parseType() (*typeNode, error) {
+	var t *typeNode
+
+	if p.peek(bracketLTokenKind) {
+		t = &typeNode{location: p.location(), kind: types.ListTypeKind}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		ofType, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		t.ofType = ofType
+
+		if err := p.expect(bracketRTokenKind); err != nil {
+			return nil, err
+		}
+	} else {
+		named, err := p.parseNamedType()
+		if err != nil {
+			return nil, err
+		}
+
+		t = named
+	}
+
+	if p.peek(bangTokenKind) {
+		nonNull := &typeNode{location: t.location, kind: types.NonNullTypeKind, ofType: t}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		return nonNull, nil
+	}
+
+	return t, nil
+}
+
+// parseNamedType parses the named type reference at the current token.
+func (p *parser) parseNamedType() (*typeNode, error) {
+	l := p.location()
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	return &typeNode{location: l, name: name.value}, nil
+}
+
+// parseDescription parses the optional description at the current token.
+func (p *parser) parseDescription() (*string, error) {
+	t := p.lexer.token
+	if t.kind != stringTokenKind && t.kind != blockStringTokenKind {
+		return nil, nil
+	}
+
+	description := t.value
+
+	return &description, p.advance()
+}
+
+// many parses the one or more items between the given open and close tokens, using the given item parser.
+func (p *parser) many(open tokenKind, close tokenKind, item func() error) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+
+		if p.peek(close) {
+			return p.advance()
+		}
+	}
+}
+
+// peek returns whether the current token is of the given kind.
+func (p *parser) peek(kind tokenKind) bool {
+	return p.lexer.token.kind == kind
+}
+
+// peekKeyword returns whether the current token is the given keyword.
+func (p *parser) peekKeyword(keyword string) bool {
+	return p.peek(nameTokenKind) && p.lexer.token.value == keyword
+}
+
+// skip advances past the current token when it is of the given kind.
+func (p *parser) skip(kind tokenKind) error {
+	if p.peek(kind) {
+		return p.advance()
+	}
+
+	return nil
+}
+
+// advance reads the next token.
+func (p *parser) advance() error {
+	return p.lexer.advance()
+}
+
+// expect advances past the current token, which must be of the given kind.
+func (p *parser) expect(kind tokenKind) error {
+	return expectToken(p.lexer, kind)
+}
+
+// expectKeyword advances past the current token, which must be the given keyword.
+func (p *parser) expectKeyword(keyword string) error {
+	if !p.peekKeyword(keyword) {
+		return p.errorAt(p.lexer.token, fmt.Sprintf("expected %q, found %s", keyword, p.lexer.token))
+	}
+
+	return p.advance()
+}
+
+// expectName advances past the current token, which must be a name, and returns it.
+func (p *parser) expectName() (*token, error) {
+	t := p.lexer.token
+	if t.kind != nameTokenKind {
+		return nil, p.errorAt(t, fmt.Sprintf("expected Name, found %s", t))
+	}
+
+	return t, p.advance()
+}
+
+// location returns the location of the current token.
+func (p *parser) location() location {
+	return location{line: p.lexer.token.line, column: p.lexer.token.column}
+}
+
+// unexpected returns the syntax error of the current token.
+func (p *parser) unexpected() error {
+	return unexpectedToken(p.lexer)
+}
+
+// errorAt returns the syntax error of the given message at the given token.
+func (p *parser) errorAt(t *token, message string) error {
+	return &SyntaxError{Message: message, Line: t.line, Column: t.column}
+}
@@ -0,0 +1,219 @@
+package sdl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestParserParse(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		fixture     string
+	}{
+		{
+			subTestName: "Handles default values, descriptions and deprecations",
+			fixture:     "defaults",
+		},
+		{
+			subTestName: "Handles schema definition, directives and type system definitions",
+			fixture:     "schema_definition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			source, err := os.ReadFile(filepath.Join("testdata", tt.fixture+".graphql"))
+			if err != nil {
+				t.Fatalf("failed to read the schema: %v", err)
+			}
+
+			result, err := NewParser().Parse(string(source))
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.Equal(t, string(source), NewPrinter().Print(result)+"\n")
+		})
+	}
+}
+
+func TestParserParseSchema(t *testing.T) {
+	source := `
+type Query {
+  pets(ratio: Float = 1.50, ids: [ID] = 7, filter: Filter = {}): [Pet]
+}
+
+interface Pet {
+  name: String
+}
+
+type Dog implements Pet {
+  name: String
+}
+
+input Filter {
+  limit: Int = 10
+}
+
+extend type Query {
+  dog: Dog
+}
+
+extend input Filter {
+  name: String
+}
+`
+
+	result, err := NewParser().Parse(source)
+	if err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+
+	schema := &result.Schema
+
+	names := []string{}
+	for _, t := range schema.Types {
+		names = append(names, t.TypeName())
+	}
+
+	assert.Equal(t, []string{
+		"Query", "Float", "ID", "Pet", "String", "Dog", "Filter", "Int", "Boolean",
+		"__Schema", "__Type", "__TypeKind", "__Field", "__InputValue", "__EnumValue", "__Directive",
+		"__DirectiveLocation",
+	}, names)
+
+	directives := []string{}
+	for _, d := range schema.Directives {
+		directives = append(directives, d.Name)
+	}
+
+	assert.Equal(t, []string{"include", "skip", "deprecated", "specifiedBy", "oneOf"}, directives)
+	assert.Equal(t, "Query", schema.QueryType.Name)
+	assert.Nil(t, schema.MutationType)
+
+	query, ok := schema.Type("Query").(*types.IntrospectionObjectType)
+	if !ok {
+		t.Fatalf("expected: *types.IntrospectionObjectType, got: %T", schema.Type("Query"))
+	}
+
+	assert.Len(t, query.Fields, 2)
+	assert.Equal(t, "dog", query.Fields[1].Name)
+
+	defaults := []string{}
+	for _, arg := range query.Fields[0].Args {
+		defaults = append(defaults, *arg.DefaultValue)
+	}
+
+	assert.Equal(t, []string{"1.5", "[7]", "{limit: 10}"}, defaults)
+
+	pet, ok := schema.Type("Pet").(*types.IntrospectionInterfaceType)
+	if !ok {
+		t.Fatalf("expected: *types.IntrospectionInterfaceType, got: %T", schema.Type("Pet"))
+	}
+
+	assert.Equal(t, []types.IntrospectionNamedTypeRef{*types.NewNamedTypeRef(types.ObjectTypeKind, "Dog")},
+		pet.PossibleTypes)
+
+	boolean, ok := schema.Type("Boolean").(*types.IntrospectionScalarType)
+	if !ok {
+		t.Fatalf("expected: *types.IntrospectionScalarType, got: %T", schema.Type("Boolean"))
+	}
+
+	assert.NotNil(t, boolean.Description)
+}
+
+func TestParserParseErrors(t *testing.T) {
+	tests := []struct {
+		subTestName    string
+		source         string
+		expectedSyntax bool
+		expectedLine   int
+		expectedColumn int
+	}{
+		{
+			subTestName:    "Handles unexpected token",
+			source:         "type Query {\n  field String\n}",
+			expectedSyntax: true,
+			expectedLine:   2,
+			expectedColumn: 9,
+		},
+		{
+			subTestName:    "Handles empty document",
+			source:         "\n  # no definitions\n",
+			expectedSyntax: true,
+			expectedLine:   3,
+			expectedColumn: 1,
+		},
+		{
+			subTestName:    "Handles executable definitions",
+			source:         "type Query {\n  a: Int\n}\n\n{ a }",
+			expectedSyntax: true,
+			expectedLine:   5,
+			expectedColumn: 1,
+		},
+		{
+			subTestName:    "Handles unknown types",
+			source:         "type Query {\n  pet: Pet\n}",
+			expectedLine:   2,
+			expectedColumn: 8,
+		},
+		{
+			subTestName:    "Handles duplicate types",
+			source:         "type Query {\n  a: Int\n}\n\nenum Query {\n  A\n}",
+			expectedLine:   5,
+			expectedColumn: 1,
+		},
+		{
+			subTestName:    "Handles extensions of undefined types",
+			source:         "type Query {\n  a: Int\n}\n\nextend type Pet {\n  b: Int\n}",
+			expectedLine:   5,
+			expectedColumn: 1,
+		},
+		{
+			subTestName:    "Handles input types used as output types",
+			source:         "type Query {\n  a: In\n}\n\ninput In {\n  b: Int\n}",
+			expectedLine:   2,
+			expectedColumn: 6,
+		},
+		{
+			subTestName:    "Handles unknown directives",
+			source:         "type Query {\n  a: Int @unknown\n}",
+			expectedLine:   2,
+			expectedColumn: 10,
+		},
+		{
+			subTestName:    "Handles reserved names",
+			source:         "type __Query {\n  a: Int\n}",
+			expectedLine:   1,
+			expectedColumn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			_, err := NewParser().Parse(tt.source)
+
+			var syntaxErr *SyntaxError
+			var schemaErr *SchemaError
+
+			switch {
+			case errors.As(err, &syntaxErr):
+				assert.True(t, tt.expectedSyntax)
+				assert.Equal(t, tt.expectedLine, syntaxErr.Line)
+				assert.Equal(t, tt.expectedColumn, syntaxErr.Column)
+			case errors.As(err, &schemaErr):
+				assert.False(t, tt.expectedSyntax)
+				assert.Equal(t, tt.expectedLine, schemaErr.Line)
+				assert.Equal(t, tt.expectedColumn, schemaErr.Column)
+			default:
+				t.Fatalf("expected: *SyntaxError or *SchemaError, got: %v", err)
+			}
+		})
+	}
+}
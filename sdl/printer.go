@@ -0,0 +1,298 @@
+package sdl
+
+import (
+	"strings"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// defaultDeprecationReason is the deprecation reason of the `@deprecated` directive without a reason.
+const defaultDeprecationReason = "No longer supported"
+
+// specifiedDirectiveNames are the names of the directives specified by graphql, which are not printed.
+var specifiedDirectiveNames = map[string]bool{
+	"include":     true,
+	"skip":        true,
+	"deprecated":  true,
+	"specifiedBy": true,
+	"oneOf":       true,
+}
+
+// Printer represents the printer of introspection schemas as graphql SDL.
+type Printer struct {
+}
+
+// NewPrinter returns a pointer to a Printer struct.
+func NewPrinter() *Printer {
+	return &Printer{}
+}
+
+// Print returns the SDL of the schema of the given introspection query result, byte for byte as graphql-js
+// `printSchema` prints the schema built by `buildClientSchema`.
+// The specified scalars, the specified directives and the introspection types are not printed.
+func (p *Printer) Print(result *types.IntrospectionQueryResult) string {
+	schema := &result.Schema
+
+	namedTypes := map[string]types.IntrospectionType{}
+	for _, t := range schema.Types {
+		namedTypes[t.TypeName()] = t
+	}
+
+	sp := &schemaPrinter{coercer: newValueCoercer(namedTypes)}
+
+	blocks := []string{}
+
+	if s := sp.schemaDefinition(schema); s != "" {
+		blocks = append(blocks, s)
+	}
+
+	for i := range schema.Directives {
+		d := &schema.Directives[i]
+		if specifiedDirectiveNames[d.Name] {
+			continue
+		}
+
+		blocks = append(blocks, sp.directive(d))
+	}
+
+	for _, t := range schema.Types {
+		if specifiedScalarNames[t.TypeName()] || strings.HasPrefix(t.TypeName(), "__") {
+			continue
+		}
+
+		blocks = append(blocks, sp.namedType(t))
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// schemaPrinter represents the printer of the definitions of a single schema.
+type schemaPrinter struct {
+	// coercer is the coercer of the default values of the schema.
+	coercer *valueCoercer
+}
+
+// schemaDefinition returns the schema definition, empty when the schema has no description and
+// its root operation types have the default names.
+func (p *schemaPrinter) schemaDefinition(schema *types.IntrospectionSchema) string {
+	if schema.Description == nil && isSchemaOfCommonNames(schema) {
+		return ""
+	}
+
+	operationTypes := []string{}
+
+	if schema.QueryType.Name != "" {
+		operationTypes = append(operationTypes, "  query: "+schema.QueryType.Name)
+	}
+
+	if schema.MutationType != nil {
+		operationTypes = append(operationTypes, "  mutation: "+schema.MutationType.Name)
+	}
+
+	if schema.SubscriptionType != nil {
+		operationTypes = append(operationTypes, "  subscription: "+schema.SubscriptionType.Name)
+	}
+
+	return description(schema.Description, "", true) + "schema {\n" + strings.Join(operationTypes, "\n") + "\n}"
+}
+
+// isSchemaOfCommonNames returns whether the root operation types of the given schema have the default names.
+func isSchemaOfCommonNames(schema *types.IntrospectionSchema) bool {
+	if schema.QueryType.Name != "" && schema.QueryType.Name != "Query" {
+		return false
+	}
+
+	if schema.MutationType != nil && schema.MutationType.Name != "Mutation" {
+		return false
+	}
+
+	if schema.SubscriptionType != nil && schema.SubscriptionType.Name != "Subscription" {
+		return false
+	}
+
+	return true
+}
+
+// directive returns the directive definition.
+func (p *schemaPrinter) directive(d *types.IntrospectionDirective) string {
+	repeatable := ""
+	if d.IsRepeatable {
+		repeatable = " repeatable"
+	}
+
+	locations := make([]string, 0, len(d.Locations))
+	for _, l := range d.Locations {
+		locations = append(locations, string(l))
+	}
+
+	return description(d.Description, "", true) + "directive @" + d.Name + p.args(d.Args, "") + repeatable +
+		" on " + strings.Join(locations, " | ")
+}
+
+// namedType returns the definition of the given named type.
+func (p *schemaPrinter) namedType(t types.IntrospectionType) string {
+	switch t := t.(type) {
+	case *types.IntrospectionScalarType:
+		return description(t.Description, "", true) + "scalar " + t.Name + specifiedBy(t.SpecifiedByURL)
+	case *types.IntrospectionObjectType:
+		return description(t.Description, "", true) + "type " + t.Name + implementedInterfaces(t.Interfaces) +
+			p.fields(t.Fields)
+	case *types.IntrospectionInterfaceType:
+		return description(t.Description, "", true) + "interface " + t.Name + implementedInterfaces(t.Interfaces) +
+			p.fields(t.Fields)
+	case *types.IntrospectionUnionType:
+		return description(t.Description, "", true) + "union " + t.Name + possibleTypes(t.PossibleTypes)
+	case *types.IntrospectionEnumType:
+		values := make([]string, 0, len(t.EnumValues))
+		for i, v := range t.EnumValues {
+			values = append(values, description(v.Description, "  ", i == 0)+"  "+v.Name+deprecated(v.DeprecationReason))
+		}
+
+		return description(t.Description, "", true) + "enum " + t.Name + block(values)
+	case *types.IntrospectionInputObjectType:
+		oneOf := ""
+		if t.IsOneOf {
+			oneOf = " @oneOf"
+		}
+
+		fields := make([]string, 0, len(t.InputFields))
+		for i := range t.InputFields {
+			f := &t.InputFields[i]
+			fields = append(fields, description(f.Description, "  ", i == 0)+"  "+p.inputValue(f))
+		}
+
+		return description(t.Description, "", true) + "input " + t.Name + oneOf + block(fields)
+	default:
+		return ""
+	}
+}
+
+// fields returns the block of the given fields.
+func (p *schemaPrinter) fields(fields []types.IntrospectionField) string {
+	lines := make([]string, 0, len(fields))
+
+	for i := range fields {
+		f := &fields[i]
+		lines = append(lines, description(f.Description, "  ", i == 0)+"  "+f.Name+p.args(f.Args, "  ")+": "+
+			f.Type.String()+deprecated(f.DeprecationReason))
+	}
+
+	return block(lines)
+}
+
+// args returns the given arguments, on a single line when none of them has a description.
+func (p *schemaPrinter) args(args []types.IntrospectionInputValue, indentation string) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	hasDescription := false
+	for _, arg := range args {
+		if arg.Description != nil && *arg.Description != "" {
+			hasDescription = true
+			break
+		}
+	}
+
+	values := make([]string, 0, len(args))
+
+	if !hasDescription {
+		for i := range args {
+			values = append(values, p.inputValue(&args[i]))
+		}
+
+		return "(" + strings.Join(values, ", ") + ")"
+	}
+
+	for i := range args {
+		values = append(values, description(args[i].Description, "  "+indentation, i == 0)+"  "+indentation+
+			p.inputValue(&args[i]))
+	}
+
+	return "(\n" + strings.Join(values, "\n") + "\n" + indentation + ")"
+}
+
+// inputValue returns the given argument or input field, with its default value and its deprecation.
+func (p *schemaPrinter) inputValue(v *types.IntrospectionInputValue) string {
+	s := v.Name + ": " + v.Type.String()
+
+	if defaultValue, ok := p.coercer.printDefaultValue(v); ok {
+		s += " = " + defaultValue
+	}
+
+	return s + deprecated(v.DeprecationReason)
+}
+
+// implementedInterfaces returns the given implemented interfaces clause.
+func implementedInterfaces(interfaces []types.IntrospectionNamedTypeRef) string {
+	if len(interfaces) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(interfaces))
+	for _, i := range interfaces {
+		names = append(names, i.Name)
+	}
+
+	return " implements " + strings.Join(names, " & ")
+}
+
+// possibleTypes returns the given union member types clause.
+func possibleTypes(refs []types.IntrospectionNamedTypeRef) string {
+	if len(refs) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(refs))
+	for _, r := range refs {
+		names = append(names, r.Name)
+	}
+
+	return " = " + strings.Join(names, " | ")
+}
+
+// block returns the given lines in braces, empty when there are no lines.
+func block(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return " {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+// deprecated returns the `@deprecated` directive of the given deprecation reason, empty when it is not deprecated.
+func deprecated(reason *string) string {
+	if reason == nil {
+		return ""
+	}
+
+	if *reason != defaultDeprecationReason {
+		return " @deprecated(reason: " + printString(*reason) + ")"
+	}
+
+	return " @deprecated"
+}
+
+// specifiedBy returns the `@specifiedBy` directive of the given specification URL, empty when it is not set.
+func specifiedBy(url *string) string {
+	if url == nil {
+		return ""
+	}
+
+	return " @specifiedBy(url: " + printString(*url) + ")"
+}
+
+// description returns the given description followed by a new line, indented with the given indentation.
+// Descriptions which are not the first in their block are preceded by an empty line.
+func description(value *string, indentation string, firstInBlock bool) string {
+	if value == nil {
+		return ""
+	}
+
+	prefix := indentation
+	if indentation != "" && !firstInBlock {
+		prefix = "\n" + indentation
+	}
+
+	return prefix + strings.ReplaceAll(printDescriptionString(*value), "\n", "\n"+indentation) + "\n"
+}
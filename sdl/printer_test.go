@@ -0,0 +1,178 @@
+package sdl
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+func TestPrinterPrint(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		fixture     string
+	}{
+		{
+			subTestName: "Handles default values, descriptions and deprecations",
+			fixture:     "defaults",
+		},
+		{
+			subTestName: "Handles schema definition, directives and type system definitions",
+			fixture:     "schema_definition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			result := testIntrospectionQueryResult(t, tt.fixture)
+
+			expected, err := os.ReadFile(filepath.Join("testdata", tt.fixture+".graphql"))
+			if err != nil {
+				t.Fatalf("failed to read the expected schema: %v", err)
+			}
+
+			assert.Equal(t, string(expected), NewPrinter().Print(result)+"\n")
+		})
+	}
+}
+
+func TestPrintBlockString(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		value       string
+		expected    string
+	}{
+		{
+			subTestName: "Handles single line",
+			value:       "A description.",
+			expected:    `"""A description."""`,
+		},
+		{
+			subTestName: "Handles empty value",
+			value:       "",
+			expected:    `""""""`,
+		},
+		{
+			subTestName: "Handles leading space",
+			value:       "  indented",
+			expected:    `"""  indented"""`,
+		},
+		{
+			subTestName: "Handles trailing quote",
+			value:       `ends with "quote"`,
+			expected:    "\"\"\"\nends with \"quote\"\n\"\"\"",
+		},
+		{
+			subTestName: "Handles triple quotes",
+			value:       `has """ in it`,
+			expected:    `"""has \""" in it"""`,
+		},
+		{
+			subTestName: "Handles indented lines",
+			value:       "first\n  second",
+			expected:    "\"\"\"\nfirst\n  second\n\"\"\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, printBlockString(tt.value))
+		})
+	}
+}
+
+func TestPrintString(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		value       string
+		expected    string
+	}{
+		{
+			subTestName: "Handles plain value",
+			value:       "plain",
+			expected:    `"plain"`,
+		},
+		{
+			subTestName: "Handles escaped characters",
+			value:       "quote \" slash \\ tab \t new line \n",
+			expected:    `"quote \" slash \\ tab \t new line \n"`,
+		},
+		{
+			subTestName: "Handles control characters",
+			value:       "\x00\x0b\x7f\u0085",
+			expected:    `"\u0000\u000B\u007F\u0085"`,
+		},
+		{
+			subTestName: "Handles unicode characters",
+			value:       "café ✅",
+			expected:    `"café ✅"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, printString(tt.value))
+		})
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		value       float64
+		expected    string
+	}{
+		{
+			subTestName: "Handles integer",
+			value:       42,
+			expected:    "42",
+		},
+		{
+			subTestName: "Handles fraction",
+			value:       -1.25,
+			expected:    "-1.25",
+		},
+		{
+			subTestName: "Handles large number",
+			value:       1e21,
+			expected:    "1e+21",
+		},
+		{
+			subTestName: "Handles small number",
+			value:       1.5e-7,
+			expected:    "1.5e-7",
+		},
+		{
+			subTestName: "Handles zero",
+			value:       0,
+			expected:    "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatNumber(tt.value))
+		})
+	}
+}
+
+// testIntrospectionQueryResult returns the decoded introspection query result of the given test fixture.
+func testIntrospectionQueryResult(t *testing.T, fixture string) *types.IntrospectionQueryResult {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", fixture+".json"))
+	if err != nil {
+		t.Fatalf("failed to read the fixture: %v", err)
+	}
+
+	result := &types.IntrospectionQueryResult{}
+	if err := json.Unmarshal(data, result); err != nil {
+		t.Fatalf("failed to decode the fixture: %v", err)
+	}
+
+	return result
+}
@@ -0,0 +1,157 @@
+package sdl
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// printString returns the given value as a quoted string, escaping the quotes, the backslashes
+// and the control characters as graphql-js does.
+func printString(value string) string {
+	b := strings.Builder{}
+	b.WriteByte('"')
+
+	for _, c := range value {
+		switch {
+		case c == '"':
+			b.WriteString(`\"`)
+		case c == '\\':
+			b.WriteString(`\\`)
+		case c == '\b':
+			b.WriteString(`\b`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\f':
+			b.WriteString(`\f`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c < 0x20 || (c >= 0x7F && c <= 0x9F):
+			b.WriteString(fmt.Sprintf(`\u%04X`, c))
+		default:
+			b.WriteRune(c)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// printBlockString returns the given value as a block string, adding the leading and trailing new lines
+// only when they improve the readability.
+func printBlockString(value string) string {
+	escapedValue := strings.ReplaceAll(value, `"""`, `\"""`)
+
+	lines := splitLines(escapedValue)
+	isSingleLine := len(lines) == 1
+
+	forceLeadingNewLine := len(lines) > 1
+	for _, line := range lines[1:] {
+		if line != "" && !isWhiteSpace(line[0]) {
+			forceLeadingNewLine = false
+			break
+		}
+	}
+
+	hasTrailingTripleQuotes := strings.HasSuffix(escapedValue, `\"""`)
+	hasTrailingQuote := strings.HasSuffix(value, `"`) && !hasTrailingTripleQuotes
+	hasTrailingSlash := strings.HasSuffix(value, `\`)
+	forceTrailingNewLine := hasTrailingQuote || hasTrailingSlash
+
+	printAsMultipleLines := !isSingleLine ||
+		utf16Length(value) > 70 ||
+		forceTrailingNewLine ||
+		forceLeadingNewLine ||
+		hasTrailingTripleQuotes
+
+	result := ""
+
+	skipLeadingNewLine := isSingleLine && value != "" && isWhiteSpace(value[0])
+	if (printAsMultipleLines && !skipLeadingNewLine) || forceLeadingNewLine {
+		result += "\n"
+	}
+
+	result += escapedValue
+
+	if printAsMultipleLines || forceTrailingNewLine {
+		result += "\n"
+	}
+
+	return `"""` + result + `"""`
+}
+
+// isPrintableAsBlockString returns whether the given value is printed as a block string without changing it,
+// the block strings can not have control characters, leading or trailing empty lines and a common indentation.
+func isPrintableAsBlockString(value string) bool {
+	if value == "" {
+		return true
+	}
+
+	isEmptyLine := true
+	hasIndent := false
+	hasCommonIndent := true
+	seenNonEmptyLine := false
+
+	for _, c := range value {
+		switch {
+		case c == '\r':
+			return false
+		case c == '\n':
+			if isEmptyLine && !seenNonEmptyLine {
+				return false
+			}
+
+			seenNonEmptyLine = true
+			isEmptyLine = true
+			hasIndent = false
+		case c == '\t' || c == ' ':
+			hasIndent = hasIndent || isEmptyLine
+		case c < 0x20:
+			return false
+		default:
+			hasCommonIndent = hasCommonIndent && hasIndent
+			isEmptyLine = false
+		}
+	}
+
+	if isEmptyLine {
+		return false
+	}
+
+	if hasCommonIndent && seenNonEmptyLine {
+		return false
+	}
+
+	return true
+}
+
+// printDescriptionString returns the given description as a block string when it is printable as such,
+// as a quoted string otherwise.
+func printDescriptionString(description string) string {
+	if isPrintableAsBlockString(description) {
+		return printBlockString(description)
+	}
+
+	return printString(description)
+}
+
+// splitLines returns the lines of the given value, split on `\r\n`, `\n` and `\r`.
+func splitLines(value string) []string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	value = strings.ReplaceAll(value, "\r", "\n")
+
+	return strings.Split(value, "\n")
+}
+
+// isWhiteSpace returns whether the given byte is a space or a tab.
+func isWhiteSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// utf16Length returns the length of the given value in UTF-16 code units, as javascript strings count it.
+func utf16Length(value string) int {
+	return len(utf16.Encode([]rune(value)))
+}
@@ -0,0 +1,645 @@
+package sdl
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/compatibility-base/types"
+)
+
+// valueKind is the kind of a graphql value literal.
+type valueKind uint
+
+const (
+	// intValueKind is the kind of the integer literals.
+	intValueKind valueKind = iota + 1
+
+	// floatValueKind is the kind of the float literals.
+	floatValueKind
+
+	// stringValueKind is the kind of the string and block string literals.
+	stringValueKind
+
+	// booleanValueKind is the kind of the `true` and `false` literals.
+	booleanValueKind
+
+	// nullValueKind is the kind of the `null` literal.
+	nullValueKind
+
+	// enumValueKind is the kind of the enum value literals.
+	enumValueKind
+
+	// listValueKind is the kind of the list literals.
+	listValueKind
+
+	// objectValueKind is the kind of the input object literals.
+	objectValueKind
+)
+
+// valueNode represents a graphql value literal.
+type valueNode struct {
+	// kind is the value kind.
+	kind valueKind
+
+	// value is the raw number, the decoded string, the boolean or the enum value name.
+	value string
+
+	// values are the items of the list literals.
+	values []*valueNode
+
+	// fields are the fields of the input object literals.
+	fields []*objectFieldNode
+}
+
+// objectFieldNode represents a field of an input object literal.
+type objectFieldNode struct {
+	// name is the field name.
+	name string
+
+	// value is the field value.
+	value *valueNode
+}
+
+// String returns the value literal as printed by graphql-js.
+func (n *valueNode) String() string {
+	switch n.kind {
+	case stringValueKind:
+		return printString(n.value)
+	case nullValueKind:
+		return "null"
+	case listValueKind:
+		values := make([]string, 0, len(n.values))
+		for _, v := range n.values {
+			values = append(values, v.String())
+		}
+
+		return "[" + strings.Join(values, ", ") + "]"
+	case objectValueKind:
+		fields := make([]string, 0, len(n.fields))
+		for _, f := range n.fields {
+			fields = append(fields, f.name+": "+f.value.String())
+		}
+
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		return n.value
+	}
+}
+
+// parseValue parses the given constant value literal source, eg. an introspection default value.
+func parseValue(source string) (*valueNode, error) {
+	l := newLexer(source)
+	if err := l.advance(); err != nil {
+		return nil, err
+	}
+
+	v, err := parseValueLiteral(l)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.token.kind != eofTokenKind {
+		return nil, unexpectedToken(l)
+	}
+
+	return v, nil
+}
+
+// parseValueLiteral parses the constant value literal at the current token of the given lexer.
+func parseValueLiteral(l *lexer) (*valueNode, error) {
+	t := l.token
+
+	switch t.kind {
+	case bracketLTokenKind:
+		if err := l.advance(); err != nil {
+			return nil, err
+		}
+
+		list := &valueNode{kind: listValueKind, values: []*valueNode{}}
+
+		for l.token.kind != bracketRTokenKind {
+			v, err := parseValueLiteral(l)
+			if err != nil {
+				return nil, err
+			}
+
+			list.values = append(list.values, v)
+		}
+
+		return list, l.advance()
+	case braceLTokenKind:
+		if err := l.advance(); err != nil {
+			return nil, err
+		}
+
+		object := &valueNode{kind: objectValueKind, fields: []*objectFieldNode{}}
+
+		for l.token.kind != braceRTokenKind {
+			if l.token.kind != nameTokenKind {
+				return nil, unexpectedToken(l)
+			}
+
+			name := l.token.value
+
+			if err := l.advance(); err != nil {
+				return nil, err
+			}
+
+			if err := expectToken(l, colonTokenKind); err != nil {
+				return nil, err
+			}
+
+			v, err := parseValueLiteral(l)
+			if err != nil {
+				return nil, err
+			}
+
+			object.fields = append(object.fields, &objectFieldNode{name: name, value: v})
+		}
+
+		return object, l.advance()
+	case intTokenKind:
+		return &valueNode{kind: intValueKind, value: t.value}, l.advance()
+	case floatTokenKind:
+		return &valueNode{kind: floatValueKind, value: t.value}, l.advance()
+	case stringTokenKind, blockStringTokenKind:
+		return &valueNode{kind: stringValueKind, value: t.value}, l.advance()
+	case nameTokenKind:
+		switch t.value {
+		case "true", "false":
+			return &valueNode{kind: booleanValueKind, value: t.value}, l.advance()
+		case "null":
+			return &valueNode{kind: nullValueKind}, l.advance()
+		default:
+			return &valueNode{kind: enumValueKind, value: t.value}, l.advance()
+		}
+	case dollarTokenKind:
+		return nil, l.errorAt(t.start, "unexpected variable in constant value")
+	default:
+		return nil, unexpectedToken(l)
+	}
+}
+
+// expectToken advances the given lexer past the current token, which must be of the given kind.
+func expectToken(l *lexer, kind tokenKind) error {
+	if l.token.kind != kind {
+		return l.errorAt(l.token.start, fmt.Sprintf("expected %q, found %s", kind.String(), l.token))
+	}
+
+	return l.advance()
+}
+
+// unexpectedToken returns the syntax error of the current token of the given lexer.
+func unexpectedToken(l *lexer) error {
+	return l.errorAt(l.token.start, fmt.Sprintf("unexpected %s", l.token))
+}
+
+// nullValue represents the coerced `null` value.
+type nullValue struct{}
+
+// objectValue represents a coerced input object value, its fields are kept in order.
+type objectValue []objectEntry
+
+// objectEntry represents a field of a coerced input object value.
+type objectEntry struct {
+	// name is the field name.
+	name string
+
+	// value is the coerced field value.
+	value any
+}
+
+// get returns the value of the field with the given name and whether the field is set.
+func (o objectValue) get(name string) (any, bool) {
+	for _, e := range o {
+		if e.name == name {
+			return e.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// integerRegexp matches the integer numbers as printed by javascript.
+var integerRegexp = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)$`)
+
+// specifiedScalarNames are the names of the scalar types specified by graphql.
+var specifiedScalarNames = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// valueCoercer represents the coercion of the value literals against the types of a schema, as graphql-js
+// `valueFromAST` and `astFromValue` do when it builds a schema and prints its default values.
+type valueCoercer struct {
+	// types are the named types of the schema, keyed by name.
+	types map[string]types.IntrospectionType
+
+	// defaults are the coerced default values of the input fields, keyed by type and field name.
+	defaults map[string]*coercedDefault
+}
+
+// coercedDefault represents the coerced default value of an input field.
+type coercedDefault struct {
+	// value is the coerced value.
+	value any
+
+	// ok is whether the default value is set and valid.
+	ok bool
+
+	// done is whether the coercion is complete, false while the default value is being coerced.
+	done bool
+}
+
+// newValueCoercer returns a pointer to a valueCoercer struct of the given named types.
+func newValueCoercer(namedTypes map[string]types.IntrospectionType) *valueCoercer {
+	return &valueCoercer{types: namedTypes, defaults: map[string]*coercedDefault{}}
+}
+
+// printDefaultValue returns the printed default value of the given input value, and whether it is printed.
+// The default value is coerced to the input value type first, so it is normalized as graphql-js prints it,
+// invalid default values are not printed and default values that can not be parsed are printed as is.
+func (c *valueCoercer) printDefaultValue(v *types.IntrospectionInputValue) (string, bool) {
+	if v.DefaultValue == nil {
+		return "", false
+	}
+
+	node, err := parseValue(*v.DefaultValue)
+	if err != nil {
+		return *v.DefaultValue, true
+	}
+
+	value, ok := c.valueFromAST(node, v.Type)
+	if !ok {
+		return "", false
+	}
+
+	printed := c.astFromValue(value, v.Type)
+	if printed == nil {
+		return "", false
+	}
+
+	return printed.String(), true
+}
+
+// inputFieldDefault returns the coerced default value of the given input field of the given input object type.
+func (c *valueCoercer) inputFieldDefault(typeName string, field *types.IntrospectionInputValue) (any, bool) {
+	key := typeName + "." + field.Name
+
+	if d, ok := c.defaults[key]; ok {
+		return d.value, d.ok && d.done
+	}
+
+	d := &coercedDefault{}
+	c.defaults[key] = d
+
+	if field.DefaultValue != nil {
+		if node, err := parseValue(*field.DefaultValue); err == nil {
+			d.value, d.ok = c.valueFromAST(node, field.Type)
+		}
+	}
+
+	d.done = true
+
+	return d.value, d.ok
+}
+
+// valueFromAST returns the value of the given literal coerced to the given type, and whether it is valid.
+func (c *valueCoercer) valueFromAST(node *valueNode, ref types.IntrospectionTypeRef) (any, bool) {
+	if nonNull, ok := ref.(*types.IntrospectionNonNullTypeRef); ok {
+		if node.kind == nullValueKind {
+			return nil, false
+		}
+
+		return c.valueFromAST(node, nonNull.OfType)
+	}
+
+	if node.kind == nullValueKind {
+		return nullValue{}, true
+	}
+
+	if list, ok := ref.(*types.IntrospectionListTypeRef); ok {
+		if node.kind != listValueKind {
+			item, ok := c.valueFromAST(node, list.OfType)
+			if !ok {
+				return nil, false
+			}
+
+			return []any{item}, true
+		}
+
+		items := make([]any, 0, len(node.values))
+
+		for _, itemNode := range node.values {
+			item, ok := c.valueFromAST(itemNode, list.OfType)
+			if !ok {
+				return nil, false
+			}
+
+			items = append(items, item)
+		}
+
+		return items, true
+	}
+
+	named := ref.NamedType()
+
+	switch named.Kind {
+	case types.InputObjectTypeKind:
+		return c.inputObjectFromAST(node, named.Name)
+	case types.EnumTypeKind:
+		if node.kind != enumValueKind {
+			return nil, false
+		}
+
+		enum, ok := c.types[named.Name].(*types.IntrospectionEnumType)
+		if !ok {
+			return nil, false
+		}
+
+		for _, v := range enum.EnumValues {
+			if v.Name == node.value {
+				return v.Name, true
+			}
+		}
+
+		return nil, false
+	case types.ScalarTypeKind:
+		return scalarFromAST(node, named.Name)
+	default:
+		return nil, false
+	}
+}
+
+// inputObjectFromAST returns the given input object literal coerced to the input object type of the given name.
+func (c *valueCoercer) inputObjectFromAST(node *valueNode, typeName string) (any, bool) {
+	if node.kind != objectValueKind {
+		return nil, false
+	}
+
+	inputObject, ok := c.types[typeName].(*types.IntrospectionInputObjectType)
+	if !ok {
+		return nil, false
+	}
+
+	fieldNodes := map[string]*valueNode{}
+	for _, f := range node.fields {
+		fieldNodes[f.name] = f.value
+	}
+
+	object := objectValue{}
+
+	for i := range inputObject.InputFields {
+		field := &inputObject.InputFields[i]
+
+		fieldNode, ok := fieldNodes[field.Name]
+		if !ok {
+			if value, ok := c.inputFieldDefault(typeName, field); ok {
+				object = append(object, objectEntry{name: field.Name, value: value})
+			} else if _, ok := field.Type.(*types.IntrospectionNonNullTypeRef); ok {
+				return nil, false
+			}
+
+			continue
+		}
+
+		value, ok := c.valueFromAST(fieldNode, field.Type)
+		if !ok {
+			return nil, false
+		}
+
+		object = append(object, objectEntry{name: field.Name, value: value})
+	}
+
+	if inputObject.IsOneOf {
+		if len(object) != 1 {
+			return nil, false
+		}
+
+		if _, ok := object[0].value.(nullValue); ok {
+			return nil, false
+		}
+	}
+
+	return object, true
+}
+
+// scalarFromAST returns the given literal coerced to the scalar type of the given name, the literals
+// of the custom scalar types are coerced without a type.
+func scalarFromAST(node *valueNode, name string) (any, bool) {
+	switch name {
+	case "Int":
+		if node.kind != intValueKind {
+			return nil, false
+		}
+
+		value, err := strconv.ParseFloat(node.value, 64)
+		if err != nil || value > math.MaxInt32 || value < math.MinInt32 {
+			return nil, false
+		}
+
+		return value, true
+	case "Float":
+		if node.kind != intValueKind && node.kind != floatValueKind {
+			return nil, false
+		}
+
+		value, err := strconv.ParseFloat(node.value, 64)
+		if err != nil || math.IsInf(value, 0) {
+			return nil, false
+		}
+
+		return value, true
+	case "String":
+		if node.kind != stringValueKind {
+			return nil, false
+		}
+
+		return node.value, true
+	case "Boolean":
+		if node.kind != booleanValueKind {
+			return nil, false
+		}
+
+		return node.value == "true", true
+	case "ID":
+		if node.kind != stringValueKind && node.kind != intValueKind {
+			return nil, false
+		}
+
+		return node.value, true
+	default:
+		return untypedValueFromAST(node), true
+	}
+}
+
+// untypedValueFromAST returns the given literal coerced without a type.
+func untypedValueFromAST(node *valueNode) any {
+	switch node.kind {
+	case intValueKind, floatValueKind:
+		value, _ := strconv.ParseFloat(node.value, 64)
+		return value
+	case booleanValueKind:
+		return node.value == "true"
+	case nullValueKind:
+		return nullValue{}
+	case listValueKind:
+		items := make([]any, 0, len(node.values))
+		for _, v := range node.values {
+			items = append(items, untypedValueFromAST(v))
+		}
+
+		return items
+	case objectValueKind:
+		object := objectValue{}
+		for _, f := range node.fields {
+			object = append(object, objectEntry{name: f.name, value: untypedValueFromAST(f.value)})
+		}
+
+		return object
+	default:
+		return node.value
+	}
+}
+
+// astFromValue returns the literal of the given coerced value of the given type, nil when it has no literal.
+func (c *valueCoercer) astFromValue(value any, ref types.IntrospectionTypeRef) *valueNode {
+	if nonNull, ok := ref.(*types.IntrospectionNonNullTypeRef); ok {
+		node := c.astFromValue(value, nonNull.OfType)
+		if node != nil && node.kind == nullValueKind {
+			return nil
+		}
+
+		return node
+	}
+
+	if _, ok := value.(nullValue); ok {
+		return &valueNode{kind: nullValueKind}
+	}
+
+	if list, ok := ref.(*types.IntrospectionListTypeRef); ok {
+		items, ok := value.([]any)
+		if !ok {
+			return c.astFromValue(value, list.OfType)
+		}
+
+		node := &valueNode{kind: listValueKind, values: []*valueNode{}}
+
+		for _, item := range items {
+			if itemNode := c.astFromValue(item, list.OfType); itemNode != nil {
+				node.values = append(node.values, itemNode)
+			}
+		}
+
+		return node
+	}
+
+	named := ref.NamedType()
+
+	if named.Kind == types.InputObjectTypeKind {
+		object, ok := value.(objectValue)
+		if !ok {
+			return nil
+		}
+
+		inputObject, ok := c.types[named.Name].(*types.IntrospectionInputObjectType)
+		if !ok {
+			return nil
+		}
+
+		node := &valueNode{kind: objectValueKind, fields: []*objectFieldNode{}}
+
+		for _, field := range inputObject.InputFields {
+			fieldValue, ok := object.get(field.Name)
+			if !ok {
+				continue
+			}
+
+			if fieldNode := c.astFromValue(fieldValue, field.Type); fieldNode != nil {
+				node.fields = append(node.fields, &objectFieldNode{name: field.Name, value: fieldNode})
+			}
+		}
+
+		return node
+	}
+
+	return leafLiteral(value, named)
+}
+
+// leafLiteral returns the literal of the given serialized scalar or enum value.
+func leafLiteral(value any, named *types.IntrospectionNamedTypeRef) *valueNode {
+	switch v := value.(type) {
+	case bool:
+		return &valueNode{kind: booleanValueKind, value: strconv.FormatBool(v)}
+	case float64:
+		s := formatNumber(v)
+		if integerRegexp.MatchString(s) {
+			return &valueNode{kind: intValueKind, value: s}
+		}
+
+		return &valueNode{kind: floatValueKind, value: s}
+	case string:
+		if named.Kind == types.EnumTypeKind {
+			return &valueNode{kind: enumValueKind, value: v}
+		}
+
+		if named.Name == "ID" && integerRegexp.MatchString(v) {
+			return &valueNode{kind: intValueKind, value: v}
+		}
+
+		return &valueNode{kind: stringValueKind, value: v}
+	default:
+		return untypedLiteral(value)
+	}
+}
+
+// untypedLiteral returns the literal of the given value coerced without a type,
+// eg. the list and object values of the custom scalar types.
+func untypedLiteral(value any) *valueNode {
+	switch v := value.(type) {
+	case nullValue:
+		return &valueNode{kind: nullValueKind}
+	case []any:
+		node := &valueNode{kind: listValueKind, values: []*valueNode{}}
+		for _, item := range v {
+			node.values = append(node.values, untypedLiteral(item))
+		}
+
+		return node
+	case objectValue:
+		node := &valueNode{kind: objectValueKind, fields: []*objectFieldNode{}}
+		for _, e := range v {
+			node.fields = append(node.fields, &objectFieldNode{name: e.name, value: untypedLiteral(e.value)})
+		}
+
+		return node
+	default:
+		return leafLiteral(value, &types.IntrospectionNamedTypeRef{Kind: types.ScalarTypeKind})
+	}
+}
+
+// formatNumber returns the given number formatted as javascript `String(number)` does.
+func formatNumber(value float64) string {
+	abs := math.Abs(value)
+
+	if abs != 0 && (abs >= 1e21 || abs < 1e-6) {
+		s := strconv.FormatFloat(value, 'e', -1, 64)
+		mantissa, exponent, _ := strings.Cut(s, "e")
+		sign := exponent[:1]
+		exponent = strings.TrimLeft(exponent[1:], "0")
+
+		return mantissa + "e" + sign + exponent
+	}
+
+	if value == 0 {
+		return "0"
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
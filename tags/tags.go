@@ -0,0 +1,224 @@
+// package tags parses the code repository tags and selects tags from version expressions.
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoMatchingTag is the error returned when no tag matches a version expression.
+var ErrNoMatchingTag = errors.New("no matching tag")
+
+// Kind is the kind of a tag.
+type Kind uint
+
+const (
+	// SemverKind is the kind of the semantic version tags, eg. `v0.8.1`.
+	SemverKind Kind = iota + 1
+
+	// EditionKind is the kind of the graphql specification edition tags, eg. `October2021`.
+	EditionKind
+)
+
+// semverRegexp matches a semantic version with an optional `v` prefix, eg. `v1.2.3-rc.1+build.5`.
+var semverRegexp = regexp.MustCompile(
+	`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`,
+)
+
+// editionRegexp matches a graphql specification edition name, eg. `October2021`.
+var editionRegexp = regexp.MustCompile(`^([A-Z][a-z]+)(\d{4})$`)
+
+// latestRegexp matches the latest version expressions of a major or minor version, eg. `latest v0.x`.
+var latestRegexp = regexp.MustCompile(`^latest v?(0|[1-9]\d*)(?:\.(0|[1-9]\d*))?(?:\.x)?$`)
+
+// Tag represents a parsed code repository tag.
+type Tag struct {
+	// Name is the tag name.
+	Name string
+
+	// Kind is the tag kind.
+	Kind Kind
+
+	// Major is the major version of the semantic version tags.
+	Major int
+
+	// Minor is the minor version of the semantic version tags.
+	Minor int
+
+	// Patch is the patch version of the semantic version tags.
+	Patch int
+
+	// Prerelease is the pre-release version of the semantic version tags, eg. `rc.1`.
+	Prerelease string
+
+	// Edition is the date of the graphql specification edition tags, the first day of its month.
+	Edition time.Time
+}
+
+// Parse parses the given tag name as a semantic version or as a graphql specification edition.
+// Returns false when the tag name is neither.
+func Parse(name string) (*Tag, bool) {
+	if m := semverRegexp.FindStringSubmatch(name); m != nil {
+		major, _ := strconv.Atoi(m[1])
+		minor, _ := strconv.Atoi(m[2])
+		patch, _ := strconv.Atoi(m[3])
+
+		return &Tag{
+			Name:       name,
+			Kind:       SemverKind,
+			Major:      major,
+			Minor:      minor,
+			Patch:      patch,
+			Prerelease: m[4],
+		}, true
+	}
+
+	if m := editionRegexp.FindStringSubmatch(name); m != nil {
+		edition, err := time.Parse("January2006", m[1]+m[2])
+		if err != nil {
+			return nil, false
+		}
+
+		return &Tag{Name: name, Kind: EditionKind, Edition: edition}, true
+	}
+
+	return nil, false
+}
+
+// IsStable returns whether the tag is a stable release, which is an edition or a semantic version
+// without pre-release version.
+func (t *Tag) IsStable() bool {
+	return t.Prerelease == ""
+}
+
+// Compare compares the given tags of the same kind, returns a negative number when a is older than b,
+// zero when they are the same version and a positive number when a is newer than b.
+// Semantic versions are older than editions.
+func Compare(a *Tag, b *Tag) int {
+	if a.Kind != b.Kind {
+		if a.Kind == SemverKind {
+			return -1
+		}
+
+		return 1
+	}
+
+	if a.Kind == EditionKind {
+		return a.Edition.Compare(b.Edition)
+	}
+
+	for _, c := range [][2]int{{a.Major, b.Major}, {a.Minor, b.Minor}, {a.Patch, b.Patch}} {
+		if c[0] != c[1] {
+			return c[0] - c[1]
+		}
+	}
+
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+// comparePrerelease compares the given pre-release versions following the semantic versioning precedence,
+// a version without pre-release version is newer than a version with one.
+func comparePrerelease(a string, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	aIDs, bIDs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		aNum, aErr := strconv.Atoi(aIDs[i])
+		bNum, bErr := strconv.Atoi(bIDs[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				return aNum - bNum
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aIDs[i], bIDs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return len(aIDs) - len(bIDs)
+}
+
+// Sorted returns the parsed tags of the given tag names from the newest to the oldest,
+// the tag names that are neither semantic versions nor editions are ignored.
+func Sorted(names []string) []*Tag {
+	result := []*Tag{}
+
+	for _, name := range names {
+		if tag, ok := Parse(name); ok {
+			result = append(result, tag)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return Compare(result[i], result[j]) > 0
+	})
+
+	return result
+}
+
+// Select returns the tag name of the given tag names that matches the given version expression:
+//   - `latest`: the newest stable semantic version.
+//   - `latest v0.x` or `latest v0.6.x`: the newest stable semantic version of the major or minor version.
+//   - `newest spec edition` or `latest edition`: the newest graphql specification edition.
+//   - any other expression is an exact tag name.
+func Select(names []string, expression string) (string, error) {
+	expr := strings.ToLower(strings.Join(strings.Fields(expression), " "))
+
+	var match func(t *Tag) bool
+
+	switch {
+	case expr == "latest":
+		match = func(t *Tag) bool {
+			return t.Kind == SemverKind && t.IsStable()
+		}
+	case expr == "newest spec edition" || expr == "latest spec edition" || expr == "latest edition":
+		match = func(t *Tag) bool {
+			return t.Kind == EditionKind
+		}
+	case latestRegexp.MatchString(expr):
+		m := latestRegexp.FindStringSubmatch(expr)
+		major, _ := strconv.Atoi(m[1])
+		minor, err := strconv.Atoi(m[2])
+		hasMinor := err == nil
+
+		match = func(t *Tag) bool {
+			return t.Kind == SemverKind && t.IsStable() && t.Major == major && (!hasMinor || t.Minor == minor)
+		}
+	default:
+		for _, name := range names {
+			if name == strings.TrimSpace(expression) {
+				return name, nil
+			}
+		}
+
+		return "", fmt.Errorf("failed to select a tag for %q: %w", expression, ErrNoMatchingTag)
+	}
+
+	for _, tag := range Sorted(names) {
+		if match(tag) {
+			return tag.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to select a tag for %q: %w", expression, ErrNoMatchingTag)
+}
@@ -0,0 +1,145 @@
+package tags
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		subTestName string
+		name        string
+		expected    *Tag
+	}{
+		{
+			subTestName: "Handles semantic version",
+			name:        "v0.8.1",
+			expected:    &Tag{Name: "v0.8.1", Kind: SemverKind, Minor: 8, Patch: 1},
+		},
+		{
+			subTestName: "Handles semantic version with pre-release and build",
+			name:        "17.0.0-alpha.2+build.1",
+			expected:    &Tag{Name: "17.0.0-alpha.2+build.1", Kind: SemverKind, Major: 17, Prerelease: "alpha.2"},
+		},
+		{
+			subTestName: "Handles specification edition",
+			name:        "October2021",
+			expected: &Tag{
+				Name:    "October2021",
+				Kind:    EditionKind,
+				Edition: time.Date(2021, time.October, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			subTestName: "Handles unknown tag",
+			name:        "release-0.1",
+		},
+		{
+			subTestName: "Handles unknown month",
+			name:        "Smarch2021",
+		},
+		{
+			subTestName: "Handles leading zero",
+			name:        "v01.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			tag, ok := Parse(tt.name)
+
+			assert.Equal(t, tt.expected != nil, ok)
+			assert.Equal(t, tt.expected, tag)
+		})
+	}
+}
+
+func TestSorted(t *testing.T) {
+	names := []string{
+		"v0.6.0", "June2018", "v1.0.0-rc.1", "v1.0.0", "v1.0.0-rc.10", "v1.0.0-beta", "release-0.1",
+		"October2021", "v0.10.0", "v1.0.0-rc.2",
+	}
+
+	expected := []string{
+		"October2021", "June2018", "v1.0.0", "v1.0.0-rc.10", "v1.0.0-rc.2", "v1.0.0-rc.1", "v1.0.0-beta",
+		"v0.10.0", "v0.6.0",
+	}
+
+	actual := []string{}
+	for _, tag := range Sorted(names) {
+		actual = append(actual, tag.Name)
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestSelect(t *testing.T) {
+	names := []string{
+		"v0.6.0", "v0.6.2", "v0.8.1", "v0.9.0-rc.1", "v1.2.0", "v2.0.0-beta", "July2015", "October2021", "June2018",
+	}
+
+	tests := []struct {
+		subTestName string
+		expression  string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			subTestName: "Handles latest",
+			expression:  "latest",
+			expected:    "v1.2.0",
+		},
+		{
+			subTestName: "Handles latest major version",
+			expression:  "latest v0.x",
+			expected:    "v0.8.1",
+		},
+		{
+			subTestName: "Handles latest minor version",
+			expression:  " Latest  0.6.x ",
+			expected:    "v0.6.2",
+		},
+		{
+			subTestName: "Handles newest spec edition",
+			expression:  "newest spec edition",
+			expected:    "October2021",
+		},
+		{
+			subTestName: "Handles exact tag name",
+			expression:  "June2018",
+			expected:    "June2018",
+		},
+		{
+			subTestName: "Handles missing major version",
+			expression:  "latest v3.x",
+			expectedErr: true,
+		},
+		{
+			subTestName: "Handles missing tag name",
+			expression:  "v0.7.0",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			name, err := Select(names, tt.expression)
+			if tt.expectedErr {
+				if !errors.Is(err, ErrNoMatchingTag) {
+					t.Fatalf("expected: %v, got: %v", ErrNoMatchingTag, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected: nil, got: %v", err)
+			}
+
+			assert.Equal(t, tt.expected, name)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// redactedSecret is the replacement of the secrets in the string outputs.
+const redactedSecret = "xxxxx"
+
+// AuthType is the type of the authentication of a private code repository.
+type AuthType uint
+
+const (
+	// SSHAgentAuthType is the authentication using the keys of the running SSH agent.
+	SSHAgentAuthType AuthType = iota + 1
+
+	// SSHKeyAuthType is the authentication using an SSH private key file.
+	SSHKeyAuthType
+
+	// TokenAuthType is the HTTP basic authentication using a token read from an environment variable.
+	TokenAuthType
+
+	// CredentialHelperAuthType is the HTTP basic authentication using a git credential helper.
+	CredentialHelperAuthType
+)
+
+// String returns the string representation of the authentication type.
+func (t AuthType) String() string {
+	switch t {
+	case SSHAgentAuthType:
+		return "ssh-agent"
+	case SSHKeyAuthType:
+		return "ssh-key"
+	case TokenAuthType:
+		return "token"
+	case CredentialHelperAuthType:
+		return "credential-helper"
+	default:
+		return "unknown"
+	}
+}
+
+// Auth represents the authentication of a private code repository.
+// Secrets are never stored, only the places they are read from, eg. environment variable names.
+type Auth struct {
+	// Type is the authentication type.
+	Type AuthType
+
+	// Username is the SSH user, defaults to `git`, or the HTTP basic authentication username.
+	Username string
+
+	// KeyPath is the SSH private key file path, used by the SSH key authentication.
+	KeyPath string
+
+	// PassphraseEnv is the name of the environment variable of the SSH private key passphrase, if any.
+	PassphraseEnv string
+
+	// TokenEnv is the name of the environment variable of the HTTP token, used by the token authentication.
+	TokenEnv string
+
+	// Helper is the git credential helper, eg. `store` or `!pass git`, defaults to the configured git helpers.
+	Helper string
+}
+
+// String returns the string summary of the authentication, without secrets.
+func (a *Auth) String() string {
+	return fmt.Sprintf("auth=%s username=%s", a.Type, a.Username)
+}
+
+// RedactURL returns the given code repository URL with its credentials redacted,
+// eg. `https://xxxxx@github.com/graphql/graphql-js` for a token username.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	user, ok := redactUser(u)
+	if !ok {
+		return rawURL
+	}
+
+	u.User = user
+
+	return u.String()
+}
+
+// RedactSecrets returns the given string with the credentials of the given code repository URL redacted,
+// eg. the error messages of a failed clone.
+// Only the user information of the URL occurrences is redacted, so that the rest of the string is kept as is.
+func RedactSecrets(s string, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return s
+	}
+
+	user, ok := redactUser(u)
+	if !ok {
+		return s
+	}
+
+	return strings.ReplaceAll(s, "://"+u.User.String()+"@", "://"+user.String()+"@")
+}
+
+// redactUser returns the user information of the given URL with its secret redacted,
+// which is the password, or the username of HTTP URLs without password since it is then a token.
+// Returns false when the URL has no secret.
+func redactUser(u *url.URL) (*url.Userinfo, bool) {
+	if u.User == nil {
+		return nil, false
+	}
+
+	if _, ok := u.User.Password(); ok {
+		return url.UserPassword(u.User.Username(), redactedSecret), true
+	}
+
+	if u.User.Username() != "" && (u.Scheme == "http" || u.Scheme == "https") {
+		return url.User(redactedSecret), true
+	}
+
+	return nil, false
+}
@@ -1,13 +1,20 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IntrospectionDirective represents an introspection directive.
 type IntrospectionDirective struct {
 	Name         string                    `json:"name"`
-	Description  string                    `json:"description"`
+	Description  *string                   `json:"description"`
 	IsRepeatable bool                      `json:"isRepeatable"`
 	Locations    []DirectiveLocation       `json:"locations"`
 	Args         []IntrospectionInputValue `json:"args"`
 }
 
+// DirectiveLocation is the location where a directive may be used.
 type DirectiveLocation string
 
 const (
@@ -32,30 +39,35 @@ const (
 	InputFieldDefinition DirectiveLocation = "INPUT_FIELD_DEFINITION"
 )
 
+// IntrospectionInputValue represents an introspection argument or input field.
 type IntrospectionInputValue struct {
 	Name              string                    `json:"name"`
-	Description       string                    `json:"description"`
-	TypeRef           IntrospectionInputTypeRef `json:"typeRef"`
-	DefaultValue      string                    `json:"defaultValue"`
+	Description       *string                   `json:"description"`
+	Type              IntrospectionInputTypeRef `json:"type"`
+	DefaultValue      *string                   `json:"defaultValue"`
 	IsDeprecated      bool                      `json:"isDeprecated"`
-	DeprecationReason string                    `json:"deprecationReason"`
+	DeprecationReason *string                   `json:"deprecationReason"`
 }
 
-type IntrospectionInputType interface {
-}
+// UnmarshalJSON decodes the given introspection input value and its type reference.
+func (v *IntrospectionInputValue) UnmarshalJSON(data []byte) error {
+	type inputValue IntrospectionInputValue
 
-type IntrospectionInputTypeRef interface {
-}
+	raw := struct {
+		*inputValue
+		Type json.RawMessage `json:"type"`
+	}{inputValue: (*inputValue)(v)}
 
-type IntrospectionNamedTypeRef interface {
-	IntrospectionInputType
-}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to decode the input value: %w", err)
+	}
 
-type IntrospectionListTypeRef interface {
-	IntrospectionInputTypeRef
-}
+	ref, err := UnmarshalIntrospectionTypeRef(raw.Type)
+	if err != nil {
+		return fmt.Errorf("failed to decode the input value %q: %w", v.Name, err)
+	}
+
+	v.Type = ref
 
-type IntrospectionNonNullTypeRef interface {
-	IntrospectionNamedTypeRef
-	IntrospectionListTypeRef
+	return nil
 }
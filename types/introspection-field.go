@@ -1,4 +1,39 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IntrospectionField represents an introspection field of an object or interface type.
 type IntrospectionField struct {
+	Name              string                     `json:"name"`
+	Description       *string                    `json:"description"`
+	Args              []IntrospectionInputValue  `json:"args"`
+	Type              IntrospectionOutputTypeRef `json:"type"`
+	IsDeprecated      bool                       `json:"isDeprecated"`
+	DeprecationReason *string                    `json:"deprecationReason"`
+}
+
+// UnmarshalJSON decodes the given introspection field and its type reference.
+func (f *IntrospectionField) UnmarshalJSON(data []byte) error {
+	type field IntrospectionField
+
+	raw := struct {
+		*field
+		Type json.RawMessage `json:"type"`
+	}{field: (*field)(f)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to decode the field: %w", err)
+	}
+
+	ref, err := UnmarshalIntrospectionTypeRef(raw.Type)
+	if err != nil {
+		return fmt.Errorf("failed to decode the field %q: %w", f.Name, err)
+	}
+
+	f.Type = ref
+
+	return nil
 }
@@ -1,5 +1,6 @@
 package types
 
+// IntrospectionQueryResult represents the data of the introspection query response.
 type IntrospectionQueryResult struct {
 	Schema IntrospectionSchema `json:"__schema"`
 }
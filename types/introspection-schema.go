@@ -1,10 +1,33 @@
 package types
 
+// IntrospectionSchema represents the result of the `__schema` introspection field.
 type IntrospectionSchema struct {
-	Description      string                   `json:"description"`
-	QueryType        IntrospectionObjectType  `json:"queryType"`
-	MutationType     IntrospectionObjectType  `json:"mutationType"`
-	SubscriptionType IntrospectionObjectType  `json:"subscriptionType"`
-	Types            IntrospectionType        `json:"types"`
-	Directives       []IntrospectionDirective `json:"directives"`
+	Description      *string                    `json:"description"`
+	QueryType        IntrospectionNamedTypeRef  `json:"queryType"`
+	MutationType     *IntrospectionNamedTypeRef `json:"mutationType"`
+	SubscriptionType *IntrospectionNamedTypeRef `json:"subscriptionType"`
+	Types            IntrospectionTypes         `json:"types"`
+	Directives       []IntrospectionDirective   `json:"directives"`
+}
+
+// Type returns the named type of the schema with the given name, nil when it is not found.
+func (s *IntrospectionSchema) Type(name string) IntrospectionType {
+	for _, t := range s.Types {
+		if t.TypeName() == name {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// Directive returns the directive of the schema with the given name, nil when it is not found.
+func (s *IntrospectionSchema) Directive(name string) *IntrospectionDirective {
+	for i := range s.Directives {
+		if s.Directives[i].Name == name {
+			return &s.Directives[i]
+		}
+	}
+
+	return nil
 }
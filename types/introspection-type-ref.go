@@ -0,0 +1,237 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// IntrospectionTypeRef represents a reference to a type, one of the IntrospectionNamedTypeRef,
+// IntrospectionListTypeRef and IntrospectionNonNullTypeRef pointers.
+// The LIST and NON_NULL wrappers reference their wrapped type with `ofType`, eg. `[User!]!`.
+type IntrospectionTypeRef interface {
+	// TypeKind returns the kind of the referenced type.
+	TypeKind() TypeKind
+
+	// NamedType returns the named type reference, unwrapping the LIST and NON_NULL wrappers.
+	NamedType() *IntrospectionNamedTypeRef
+
+	// Equal returns whether the given type reference references the same type with the same wrappers.
+	Equal(other IntrospectionTypeRef) bool
+
+	// String returns the type reference as written in graphql, eg. `[User!]!`.
+	String() string
+}
+
+// IntrospectionInputTypeRef represents a reference to the input type of an argument or input field.
+type IntrospectionInputTypeRef = IntrospectionTypeRef
+
+// IntrospectionOutputTypeRef represents a reference to the output type of a field.
+type IntrospectionOutputTypeRef = IntrospectionTypeRef
+
+// errMissingOfType is the error returned when a LIST or NON_NULL type reference has no wrapped type.
+var errMissingOfType = errors.New("missing ofType")
+
+// IntrospectionNamedTypeRef represents a reference to a named type.
+type IntrospectionNamedTypeRef struct {
+	Kind TypeKind `json:"kind"`
+	Name string   `json:"name"`
+}
+
+// NewNamedTypeRef returns a pointer to a IntrospectionNamedTypeRef struct referencing the given named type.
+func NewNamedTypeRef(kind TypeKind, name string) *IntrospectionNamedTypeRef {
+	return &IntrospectionNamedTypeRef{Kind: kind, Name: name}
+}
+
+// TypeKind returns the kind of the named type.
+func (r *IntrospectionNamedTypeRef) TypeKind() TypeKind {
+	return r.Kind
+}
+
+// NamedType returns the named type reference itself.
+func (r *IntrospectionNamedTypeRef) NamedType() *IntrospectionNamedTypeRef {
+	return r
+}
+
+// Equal returns whether the given type reference references the same named type.
+func (r *IntrospectionNamedTypeRef) Equal(other IntrospectionTypeRef) bool {
+	o, ok := other.(*IntrospectionNamedTypeRef)
+
+	return ok && o != nil && r.Kind == o.Kind && r.Name == o.Name
+}
+
+// String returns the name of the named type.
+func (r *IntrospectionNamedTypeRef) String() string {
+	return r.Name
+}
+
+// IntrospectionListTypeRef represents a reference to a list of the wrapped type.
+type IntrospectionListTypeRef struct {
+	Kind   TypeKind             `json:"kind"`
+	OfType IntrospectionTypeRef `json:"ofType"`
+}
+
+// NewListTypeRef returns a pointer to a IntrospectionListTypeRef struct wrapping the given type reference.
+func NewListTypeRef(ofType IntrospectionTypeRef) *IntrospectionListTypeRef {
+	return &IntrospectionListTypeRef{Kind: ListTypeKind, OfType: ofType}
+}
+
+// TypeKind returns the LIST kind.
+func (r *IntrospectionListTypeRef) TypeKind() TypeKind {
+	return ListTypeKind
+}
+
+// NamedType returns the named type reference of the wrapped type.
+func (r *IntrospectionListTypeRef) NamedType() *IntrospectionNamedTypeRef {
+	return r.OfType.NamedType()
+}
+
+// Equal returns whether the given type reference is a list of the same wrapped type.
+func (r *IntrospectionListTypeRef) Equal(other IntrospectionTypeRef) bool {
+	o, ok := other.(*IntrospectionListTypeRef)
+
+	return ok && o != nil && r.OfType.Equal(o.OfType)
+}
+
+// String returns the list type reference, eg. `[User]`.
+func (r *IntrospectionListTypeRef) String() string {
+	return "[" + r.OfType.String() + "]"
+}
+
+// UnmarshalJSON decodes the given list type reference and its wrapped type.
+func (r *IntrospectionListTypeRef) UnmarshalJSON(data []byte) error {
+	ofType, err := unmarshalOfType(data, ListTypeKind)
+	if err != nil {
+		return err
+	}
+
+	*r = IntrospectionListTypeRef{Kind: ListTypeKind, OfType: ofType}
+
+	return nil
+}
+
+// IntrospectionNonNullTypeRef represents a reference to the non-null wrapped type.
+type IntrospectionNonNullTypeRef struct {
+	Kind   TypeKind             `json:"kind"`
+	OfType IntrospectionTypeRef `json:"ofType"`
+}
+
+// NewNonNullTypeRef returns a pointer to a IntrospectionNonNullTypeRef struct wrapping the given type reference.
+func NewNonNullTypeRef(ofType IntrospectionTypeRef) *IntrospectionNonNullTypeRef {
+	return &IntrospectionNonNullTypeRef{Kind: NonNullTypeKind, OfType: ofType}
+}
+
+// TypeKind returns the NON_NULL kind.
+func (r *IntrospectionNonNullTypeRef) TypeKind() TypeKind {
+	return NonNullTypeKind
+}
+
+// NamedType returns the named type reference of the wrapped type.
+func (r *IntrospectionNonNullTypeRef) NamedType() *IntrospectionNamedTypeRef {
+	return r.OfType.NamedType()
+}
+
+// Equal returns whether the given type reference is the same non-null wrapped type.
+func (r *IntrospectionNonNullTypeRef) Equal(other IntrospectionTypeRef) bool {
+	o, ok := other.(*IntrospectionNonNullTypeRef)
+
+	return ok && o != nil && r.OfType.Equal(o.OfType)
+}
+
+// String returns the non-null type reference, eg. `User!`.
+func (r *IntrospectionNonNullTypeRef) String() string {
+	return r.OfType.String() + "!"
+}
+
+// UnmarshalJSON decodes the given non-null type reference and its wrapped type,
+// a non-null type reference can not wrap another non-null type reference.
+func (r *IntrospectionNonNullTypeRef) UnmarshalJSON(data []byte) error {
+	ofType, err := unmarshalOfType(data, NonNullTypeKind)
+	if err != nil {
+		return err
+	}
+
+	if ofType.TypeKind() == NonNullTypeKind {
+		return fmt.Errorf("failed to decode the type reference: unexpected %s of %s", NonNullTypeKind, NonNullTypeKind)
+	}
+
+	*r = IntrospectionNonNullTypeRef{Kind: NonNullTypeKind, OfType: ofType}
+
+	return nil
+}
+
+// UnmarshalIntrospectionTypeRef decodes the given type reference into the type reference of its kind,
+// the LIST and NON_NULL wrappers are decoded recursively.
+func UnmarshalIntrospectionTypeRef(data []byte) (IntrospectionTypeRef, error) {
+	var header struct {
+		Kind TypeKind `json:"kind"`
+		Name *string  `json:"name"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode the type reference: %w", err)
+	}
+
+	switch header.Kind {
+	case ListTypeKind:
+		ref := &IntrospectionListTypeRef{}
+		if err := json.Unmarshal(data, ref); err != nil {
+			return nil, err
+		}
+
+		return ref, nil
+	case NonNullTypeKind:
+		ref := &IntrospectionNonNullTypeRef{}
+		if err := json.Unmarshal(data, ref); err != nil {
+			return nil, err
+		}
+
+		return ref, nil
+	case ScalarTypeKind, ObjectTypeKind, InterfaceTypeKind, UnionTypeKind, EnumTypeKind, InputObjectTypeKind:
+		if header.Name == nil || *header.Name == "" {
+			return nil, fmt.Errorf("failed to decode the type reference: missing %s name", header.Kind)
+		}
+
+		return NewNamedTypeRef(header.Kind, *header.Name), nil
+	default:
+		return nil, fmt.Errorf("failed to decode the type reference: unexpected kind: %q", header.Kind)
+	}
+}
+
+// unmarshalOfType decodes the wrapped type reference of the given LIST or NON_NULL type reference.
+func unmarshalOfType(data []byte, kind TypeKind) (IntrospectionTypeRef, error) {
+	var wrapper struct {
+		OfType json.RawMessage `json:"ofType"`
+	}
+
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to decode the type reference: %w", err)
+	}
+
+	if len(wrapper.OfType) == 0 || string(wrapper.OfType) == "null" {
+		return nil, fmt.Errorf("failed to decode the %s type reference: %w", kind, errMissingOfType)
+	}
+
+	return UnmarshalIntrospectionTypeRef(wrapper.OfType)
+}
+
+// NullableTypeRef returns the given type reference without its NON_NULL wrapper.
+func NullableTypeRef(ref IntrospectionTypeRef) IntrospectionTypeRef {
+	if nonNull, ok := ref.(*IntrospectionNonNullTypeRef); ok {
+		return nonNull.OfType
+	}
+
+	return ref
+}
+
+// TypeRefDepth returns the number of LIST and NON_NULL wrappers of the given type reference.
+func TypeRefDepth(ref IntrospectionTypeRef) int {
+	switch r := ref.(type) {
+	case *IntrospectionListTypeRef:
+		return 1 + TypeRefDepth(r.OfType)
+	case *IntrospectionNonNullTypeRef:
+		return 1 + TypeRefDepth(r.OfType)
+	default:
+		return 0
+	}
+}
@@ -1,50 +1,268 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TypeKind is the kind of a graphql type, as returned by the `__Type.kind` introspection field.
+type TypeKind string
+
+const (
+	// ScalarTypeKind is the kind of the scalar types.
+	ScalarTypeKind TypeKind = "SCALAR"
+
+	// ObjectTypeKind is the kind of the object types.
+	ObjectTypeKind TypeKind = "OBJECT"
+
+	// InterfaceTypeKind is the kind of the interface types.
+	InterfaceTypeKind TypeKind = "INTERFACE"
+
+	// UnionTypeKind is the kind of the union types.
+	UnionTypeKind TypeKind = "UNION"
+
+	// EnumTypeKind is the kind of the enum types.
+	EnumTypeKind TypeKind = "ENUM"
+
+	// InputObjectTypeKind is the kind of the input object types.
+	InputObjectTypeKind TypeKind = "INPUT_OBJECT"
+
+	// ListTypeKind is the kind of the list type wrappers.
+	ListTypeKind TypeKind = "LIST"
+
+	// NonNullTypeKind is the kind of the non-null type wrappers.
+	NonNullTypeKind TypeKind = "NON_NULL"
+)
+
+// IntrospectionType represents a named type of the introspection schema, one of the
+// IntrospectionScalarType, IntrospectionObjectType, IntrospectionInterfaceType, IntrospectionUnionType,
+// IntrospectionEnumType and IntrospectionInputObjectType pointers.
 type IntrospectionType interface {
+	// TypeKind returns the kind of the type.
+	TypeKind() TypeKind
+
+	// TypeName returns the name of the type.
+	TypeName() string
+
+	// TypeDescription returns the description of the type, nil when it has no description.
+	TypeDescription() *string
 }
 
+// IntrospectionScalarType represents an introspection scalar type.
 type IntrospectionScalarType struct {
-	Kind           string `json:"kind"`
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	SpecifiedByURL string `json:"specifiedByURL"`
+	Kind           TypeKind `json:"kind"`
+	Name           string   `json:"name"`
+	Description    *string  `json:"description"`
+	SpecifiedByURL *string  `json:"specifiedByURL"`
+}
+
+// TypeKind returns the kind of the scalar type.
+func (t *IntrospectionScalarType) TypeKind() TypeKind {
+	return ScalarTypeKind
+}
+
+// TypeName returns the name of the scalar type.
+func (t *IntrospectionScalarType) TypeName() string {
+	return t.Name
 }
 
+// TypeDescription returns the description of the scalar type.
+func (t *IntrospectionScalarType) TypeDescription() *string {
+	return t.Description
+}
+
+// IntrospectionObjectType represents an introspection object type.
 type IntrospectionObjectType struct {
-	Kind        string             `json:"kind"`
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
-	Fields      IntrospectionField `json:"fields"`
-	Interfaces  interface{}        `json:"interfaces"`
+	Kind        TypeKind                    `json:"kind"`
+	Name        string                      `json:"name"`
+	Description *string                     `json:"description"`
+	Fields      []IntrospectionField        `json:"fields"`
+	Interfaces  []IntrospectionNamedTypeRef `json:"interfaces"`
+}
+
+// TypeKind returns the kind of the object type.
+func (t *IntrospectionObjectType) TypeKind() TypeKind {
+	return ObjectTypeKind
+}
+
+// TypeName returns the name of the object type.
+func (t *IntrospectionObjectType) TypeName() string {
+	return t.Name
+}
+
+// TypeDescription returns the description of the object type.
+func (t *IntrospectionObjectType) TypeDescription() *string {
+	return t.Description
 }
 
+// IntrospectionInterfaceType represents an introspection interface type.
 type IntrospectionInterfaceType struct {
-	Kind        string             `json:"kind"`
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
-	Fields      IntrospectionField `json:"fields"`
-	Interfaces  interface{}        `json:"interfaces"`
-	// possibleTypes IntrospectionObjectType    `json:"possibleTypes"`
+	Kind          TypeKind                    `json:"kind"`
+	Name          string                      `json:"name"`
+	Description   *string                     `json:"description"`
+	Fields        []IntrospectionField        `json:"fields"`
+	Interfaces    []IntrospectionNamedTypeRef `json:"interfaces"`
+	PossibleTypes []IntrospectionNamedTypeRef `json:"possibleTypes"`
+}
+
+// TypeKind returns the kind of the interface type.
+func (t *IntrospectionInterfaceType) TypeKind() TypeKind {
+	return InterfaceTypeKind
+}
+
+// TypeName returns the name of the interface type.
+func (t *IntrospectionInterfaceType) TypeName() string {
+	return t.Name
+}
+
+// TypeDescription returns the description of the interface type.
+func (t *IntrospectionInterfaceType) TypeDescription() *string {
+	return t.Description
 }
 
+// IntrospectionUnionType represents an introspection union type.
 type IntrospectionUnionType struct {
-	Kind          string                  `json:"kind"`
-	Name          string                  `json:"name"`
-	Description   string                  `json:"description"`
-	PossibleTypes IntrospectionObjectType `json:"possibleTypes"`
+	Kind          TypeKind                    `json:"kind"`
+	Name          string                      `json:"name"`
+	Description   *string                     `json:"description"`
+	PossibleTypes []IntrospectionNamedTypeRef `json:"possibleTypes"`
+}
+
+// TypeKind returns the kind of the union type.
+func (t *IntrospectionUnionType) TypeKind() TypeKind {
+	return UnionTypeKind
+}
+
+// TypeName returns the name of the union type.
+func (t *IntrospectionUnionType) TypeName() string {
+	return t.Name
+}
+
+// TypeDescription returns the description of the union type.
+func (t *IntrospectionUnionType) TypeDescription() *string {
+	return t.Description
 }
 
+// IntrospectionEnumType represents an introspection enum type.
 type IntrospectionEnumType struct {
-	Kind        string `json:"kind"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	// enumValues  IntrospectionEnumValue `json:"enumValues"`
+	Kind        TypeKind                 `json:"kind"`
+	Name        string                   `json:"name"`
+	Description *string                  `json:"description"`
+	EnumValues  []IntrospectionEnumValue `json:"enumValues"`
+}
+
+// TypeKind returns the kind of the enum type.
+func (t *IntrospectionEnumType) TypeKind() TypeKind {
+	return EnumTypeKind
+}
+
+// TypeName returns the name of the enum type.
+func (t *IntrospectionEnumType) TypeName() string {
+	return t.Name
+}
+
+// TypeDescription returns the description of the enum type.
+func (t *IntrospectionEnumType) TypeDescription() *string {
+	return t.Description
 }
 
+// IntrospectionInputObjectType represents an introspection input object type.
 type IntrospectionInputObjectType struct {
-	Kind        string `json:"kind"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	// inputFields IntrospectionInputValue `json:"inputFields"`
-	IsOneOf bool `json:"isOneOf"`
+	Kind        TypeKind                  `json:"kind"`
+	Name        string                    `json:"name"`
+	Description *string                   `json:"description"`
+	InputFields []IntrospectionInputValue `json:"inputFields"`
+	IsOneOf     bool                      `json:"isOneOf"`
+}
+
+// TypeKind returns the kind of the input object type.
+func (t *IntrospectionInputObjectType) TypeKind() TypeKind {
+	return InputObjectTypeKind
+}
+
+// TypeName returns the name of the input object type.
+func (t *IntrospectionInputObjectType) TypeName() string {
+	return t.Name
+}
+
+// TypeDescription returns the description of the input object type.
+func (t *IntrospectionInputObjectType) TypeDescription() *string {
+	return t.Description
+}
+
+// IntrospectionEnumValue represents an introspection enum value.
+type IntrospectionEnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+// IntrospectionTypes represents the named types of the introspection schema,
+// each type is decoded into the concrete introspection type of its kind.
+type IntrospectionTypes []IntrospectionType
+
+// UnmarshalJSON decodes the given introspection types list.
+func (t *IntrospectionTypes) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return fmt.Errorf("failed to decode the introspection types: %w", err)
+	}
+
+	if raws == nil {
+		*t = nil
+		return nil
+	}
+
+	types := make(IntrospectionTypes, 0, len(raws))
+
+	for _, raw := range raws {
+		introspectionType, err := UnmarshalIntrospectionType(raw)
+		if err != nil {
+			return err
+		}
+
+		types = append(types, introspectionType)
+	}
+
+	*t = types
+
+	return nil
+}
+
+// UnmarshalIntrospectionType decodes the given introspection type into the concrete introspection type of its kind.
+func UnmarshalIntrospectionType(data []byte) (IntrospectionType, error) {
+	var header struct {
+		Kind TypeKind `json:"kind"`
+		Name string   `json:"name"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode the introspection type: %w", err)
+	}
+
+	var introspectionType IntrospectionType
+
+	switch header.Kind {
+	case ScalarTypeKind:
+		introspectionType = &IntrospectionScalarType{}
+	case ObjectTypeKind:
+		introspectionType = &IntrospectionObjectType{}
+	case InterfaceTypeKind:
+		introspectionType = &IntrospectionInterfaceType{}
+	case UnionTypeKind:
+		introspectionType = &IntrospectionUnionType{}
+	case EnumTypeKind:
+		introspectionType = &IntrospectionEnumType{}
+	case InputObjectTypeKind:
+		introspectionType = &IntrospectionInputObjectType{}
+	default:
+		return nil, fmt.Errorf("unexpected introspection type %q kind: %q", header.Name, header.Kind)
+	}
+
+	if err := json.Unmarshal(data, introspectionType); err != nil {
+		return nil, fmt.Errorf("failed to decode the introspection type %q: %w", header.Name, err)
+	}
+
+	return introspectionType, nil
 }
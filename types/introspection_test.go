@@ -0,0 +1,332 @@
+package types
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntrospectionQueryResultUnmarshalJSON(t *testing.T) {
+	result := testIntrospectionQueryResult(t)
+	schema := result.Schema
+
+	assert.Equal(t, "The pet store schema.", *schema.Description)
+	assert.Equal(t, IntrospectionNamedTypeRef{Kind: ObjectTypeKind, Name: "Query"}, schema.QueryType)
+	assert.Nil(t, schema.MutationType)
+	assert.Nil(t, schema.SubscriptionType)
+
+	tests := []struct {
+		subTestName  string
+		name         string
+		expectedKind TypeKind
+	}{
+		{
+			subTestName:  "Handles object type",
+			name:         "Query",
+			expectedKind: ObjectTypeKind,
+		},
+		{
+			subTestName:  "Handles interface type",
+			name:         "Pet",
+			expectedKind: InterfaceTypeKind,
+		},
+		{
+			subTestName:  "Handles union type",
+			name:         "Animal",
+			expectedKind: UnionTypeKind,
+		},
+		{
+			subTestName:  "Handles enum type",
+			name:         "Size",
+			expectedKind: EnumTypeKind,
+		},
+		{
+			subTestName:  "Handles input object type",
+			name:         "PetFilter",
+			expectedKind: InputObjectTypeKind,
+		},
+		{
+			subTestName:  "Handles scalar type",
+			name:         "DateTime",
+			expectedKind: ScalarTypeKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			introspectionType := schema.Type(tt.name)
+			if introspectionType == nil {
+				t.Fatalf("expected: %v, got: nil", tt.name)
+			}
+
+			assert.Equal(t, tt.expectedKind, introspectionType.TypeKind())
+			assert.Equal(t, tt.name, introspectionType.TypeName())
+		})
+	}
+
+	query := schema.Type("Query").(*IntrospectionObjectType)
+	assert.Len(t, query.Fields, 2)
+	assert.Equal(t, "The pets of the store.", *query.Fields[0].Description)
+	assert.Equal(t, "[Pet!]!", query.Fields[0].Type.String())
+	assert.Equal(t, "Int", query.Fields[0].Args[0].Type.String())
+	assert.Equal(t, "10", *query.Fields[0].Args[0].DefaultValue)
+	assert.Nil(t, query.Fields[1].Args[0].DefaultValue)
+	assert.True(t, query.Fields[1].IsDeprecated)
+	assert.Equal(t, "Use `pets`.", *query.Fields[1].DeprecationReason)
+
+	pet := schema.Type("Pet").(*IntrospectionInterfaceType)
+	assert.Equal(t, []IntrospectionNamedTypeRef{{Kind: ObjectTypeKind, Name: "Dog"}}, pet.PossibleTypes)
+
+	dog := schema.Type("Dog").(*IntrospectionObjectType)
+	assert.Equal(t, "A good boy.", *dog.Description)
+	assert.Equal(t, []IntrospectionNamedTypeRef{{Kind: InterfaceTypeKind, Name: "Pet"}}, dog.Interfaces)
+
+	animal := schema.Type("Animal").(*IntrospectionUnionType)
+	assert.Equal(t, []IntrospectionNamedTypeRef{{Kind: ObjectTypeKind, Name: "Dog"}}, animal.PossibleTypes)
+
+	size := schema.Type("Size").(*IntrospectionEnumType)
+	assert.Len(t, size.EnumValues, 2)
+	assert.True(t, size.EnumValues[1].IsDeprecated)
+	assert.Equal(t, "Too big.", *size.EnumValues[1].DeprecationReason)
+
+	filter := schema.Type("PetFilter").(*IntrospectionInputObjectType)
+	assert.True(t, filter.IsOneOf)
+	assert.Len(t, filter.InputFields, 2)
+	assert.Equal(t, "SMALL", *filter.InputFields[1].DefaultValue)
+
+	dateTime := schema.Type("DateTime").(*IntrospectionScalarType)
+	assert.Equal(t, "https://scalars.graphql.org/andimarek/date-time", *dateTime.SpecifiedByURL)
+
+	tag := schema.Directive("tag")
+	if tag == nil {
+		t.Fatalf("expected: %v, got: nil", "tag")
+	}
+
+	assert.True(t, tag.IsRepeatable)
+	assert.Equal(t, []DirectiveLocation{FieldDefinition, Object}, tag.Locations)
+	assert.Nil(t, schema.Directive("unknown"))
+	assert.Nil(t, schema.Type("Unknown"))
+}
+
+func TestIntrospectionQueryResultRoundTrip(t *testing.T) {
+	result := testIntrospectionQueryResult(t)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	decoded := IntrospectionQueryResult{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	assert.Equal(t, result, decoded)
+}
+
+func TestUnmarshalIntrospectionType(t *testing.T) {
+	tests := []struct {
+		subTestName   string
+		data          string
+		expected      IntrospectionType
+		expectedError bool
+	}{
+		{
+			subTestName: "Handles scalar type",
+			data:        `{"kind": "SCALAR", "name": "ID", "description": null}`,
+			expected:    &IntrospectionScalarType{Kind: ScalarTypeKind, Name: "ID"},
+		},
+		{
+			subTestName:   "Handles wrapper kind",
+			data:          `{"kind": "LIST", "name": null}`,
+			expectedError: true,
+		},
+		{
+			subTestName:   "Handles missing kind",
+			data:          `{"name": "ID"}`,
+			expectedError: true,
+		},
+		{
+			subTestName:   "Handles invalid json",
+			data:          `{"kind": `,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			actual, err := UnmarshalIntrospectionType([]byte(tt.data))
+			if tt.expectedError {
+				if err == nil {
+					t.Fatalf("expected: error, got: %v", actual)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestUnmarshalIntrospectionTypeRef(t *testing.T) {
+	tests := []struct {
+		subTestName    string
+		data           string
+		expected       IntrospectionTypeRef
+		expectedString string
+		expectedDepth  int
+		expectedError  bool
+	}{
+		{
+			subTestName:    "Handles named type",
+			data:           `{"kind": "OBJECT", "name": "User", "ofType": null}`,
+			expected:       NewNamedTypeRef(ObjectTypeKind, "User"),
+			expectedString: "User",
+			expectedDepth:  0,
+		},
+		{
+			subTestName: "Handles non-null list of non-null type",
+			data: `{"kind": "NON_NULL", "name": null, "ofType": {"kind": "LIST", "name": null, "ofType": ` +
+				`{"kind": "NON_NULL", "name": null, "ofType": {"kind": "OBJECT", "name": "User", "ofType": null}}}}`,
+			expected:       NewNonNullTypeRef(NewListTypeRef(NewNonNullTypeRef(NewNamedTypeRef(ObjectTypeKind, "User")))),
+			expectedString: "[User!]!",
+			expectedDepth:  3,
+		},
+		{
+			subTestName: "Handles nested lists",
+			data: `{"kind": "LIST", "name": null, "ofType": {"kind": "LIST", "name": null, "ofType": ` +
+				`{"kind": "SCALAR", "name": "Int", "ofType": null}}}`,
+			expected:       NewListTypeRef(NewListTypeRef(NewNamedTypeRef(ScalarTypeKind, "Int"))),
+			expectedString: "[[Int]]",
+			expectedDepth:  2,
+		},
+		{
+			subTestName:   "Handles missing of type",
+			data:          `{"kind": "LIST", "name": null, "ofType": null}`,
+			expectedError: true,
+		},
+		{
+			subTestName: "Handles non-null of non-null",
+			data: `{"kind": "NON_NULL", "name": null, "ofType": {"kind": "NON_NULL", "name": null, "ofType": ` +
+				`{"kind": "SCALAR", "name": "Int", "ofType": null}}}`,
+			expectedError: true,
+		},
+		{
+			subTestName:   "Handles missing name",
+			data:          `{"kind": "SCALAR", "name": null, "ofType": null}`,
+			expectedError: true,
+		},
+		{
+			subTestName:   "Handles unexpected kind",
+			data:          `{"kind": "UNKNOWN", "name": "User", "ofType": null}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			actual, err := UnmarshalIntrospectionTypeRef([]byte(tt.data))
+			if tt.expectedError {
+				if err == nil {
+					t.Fatalf("expected: error, got: %v", actual)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+
+			assert.Equal(t, tt.expected, actual)
+			assert.True(t, tt.expected.Equal(actual))
+			assert.Equal(t, tt.expectedString, actual.String())
+			assert.Equal(t, tt.expectedDepth, TypeRefDepth(actual))
+		})
+	}
+}
+
+func TestIntrospectionTypeRefEqual(t *testing.T) {
+	user := NewNamedTypeRef(ObjectTypeKind, "User")
+
+	tests := []struct {
+		subTestName string
+		ref         IntrospectionTypeRef
+		other       IntrospectionTypeRef
+		expected    bool
+	}{
+		{
+			subTestName: "Handles same named type",
+			ref:         user,
+			other:       NewNamedTypeRef(ObjectTypeKind, "User"),
+			expected:    true,
+		},
+		{
+			subTestName: "Handles different named type",
+			ref:         user,
+			other:       NewNamedTypeRef(ObjectTypeKind, "Post"),
+			expected:    false,
+		},
+		{
+			subTestName: "Handles different wrappers",
+			ref:         NewListTypeRef(NewNonNullTypeRef(user)),
+			other:       NewNonNullTypeRef(NewListTypeRef(user)),
+			expected:    false,
+		},
+		{
+			subTestName: "Handles same wrappers",
+			ref:         NewNonNullTypeRef(NewListTypeRef(user)),
+			other:       NewNonNullTypeRef(NewListTypeRef(NewNamedTypeRef(ObjectTypeKind, "User"))),
+			expected:    true,
+		},
+		{
+			subTestName: "Handles nil type reference",
+			ref:         user,
+			other:       nil,
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subTestName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.ref.Equal(tt.other))
+		})
+	}
+}
+
+func TestIntrospectionTypeRefUnwrap(t *testing.T) {
+	user := NewNamedTypeRef(ObjectTypeKind, "User")
+	list := NewListTypeRef(NewNonNullTypeRef(user))
+	ref := NewNonNullTypeRef(list)
+
+	assert.Equal(t, user, ref.NamedType())
+	assert.Equal(t, user, list.NamedType())
+	assert.Equal(t, user, user.NamedType())
+	assert.Equal(t, IntrospectionTypeRef(list), NullableTypeRef(ref))
+	assert.Equal(t, IntrospectionTypeRef(list), NullableTypeRef(list))
+}
+
+// testIntrospectionQueryResult returns the decoded introspection query result of the test fixture.
+func testIntrospectionQueryResult(t *testing.T) IntrospectionQueryResult {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "introspection.json"))
+	if err != nil {
+		t.Fatalf("failed to read the fixture: %v", err)
+	}
+
+	result := IntrospectionQueryResult{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("failed to decode the fixture: %v", err)
+	}
+
+	return result
+}
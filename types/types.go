@@ -17,6 +17,20 @@ const (
 	RefImplementationType
 )
 
+// SourceType is the type of the source a code repository is pulled from.
+type SourceType uint
+
+const (
+	// GitSourceType is the source type of a git remote, it is the default source type.
+	GitSourceType SourceType = iota + 1
+
+	// ArchiveSourceType is the source type of a tar.gz or zip archive, from a local path or an HTTP server.
+	ArchiveSourceType
+
+	// LocalSourceType is the source type of an already existing local directory.
+	LocalSourceType
+)
+
 // Repository represents the code repository of a graphql implementation.
 type Repository struct {
 	// Name is the code repository name.
@@ -30,18 +44,45 @@ type Repository struct {
 
 	// Dir is the code repository directory path.
 	Dir string
+
+	// Source is the type of the source the code repository is pulled from, defaults to a git remote.
+	Source SourceType
+
+	// Checksum is the expected sha256 checksum of the archive, eg. `sha256:<hex>`, required by archive sources.
+	Checksum string
+
+	// InsecureSkipChecksum represents whether or not archive sources skip the checksum verification,
+	// so that archives without a known checksum can be pulled.
+	InsecureSkipChecksum bool
+
+	// Commit is the optional pinned commit hash, the pull fails when the checked out commit does not match.
+	Commit string
+
+	// TreeHash is the optional pinned git tree hash, the pull fails when the pulled content does not match.
+	TreeHash string
+
+	// IncludePaths are the optional path patterns the compatibility suite needs, eg. `src/**/__tests__`,
+	// git sources then use a shallow, single-branch and sparse checkout, the whole tree is pulled when empty.
+	// Patterns are checked out by their leading directory before the first wildcard, eg. the whole `spec`
+	// directory for `spec/*.md`, and a pattern starting with a wildcard checks out the whole tree.
+	// Commit hash reference names can not be shallow cloned, they fall back to a full clone that is still
+	// sparsely checked out.
+	IncludePaths []string
+
+	// Auth is the optional authentication of the private code repository.
+	Auth *Auth
 }
 
-// String returns the string summary of the code repository.
+// String returns the string summary of the code repository, the URL credentials are redacted.
 func (r *Repository) String(prefix string) string {
 	base := fmt.Sprintf("%s: %s\n", prefix, taggedRepoURL)
 
-	return fmt.Sprintf(base, r.URL, r.ReferenceName)
+	return fmt.Sprintf(base, RedactURL(r.URL), r.ReferenceName)
 }
 
 // Introspection represents a graphql introspection.
 type Introspection struct {
-	// Query is the introspection query.
+	// Query is the introspection query, eg. generated by `introspection.EditionQuery`.
 	Query string
 }
 
@@ -84,3 +125,12 @@ type ImplementationIntrospection struct {
 	// QueryResult contains the result of the introspection query.
 	QueryResult IntrospectionQueryResult
 }
+
+// Metric represents a named metric value, as displayed in a table row.
+type Metric struct {
+	// Name is the metric name, eg. `Last Commit Date`.
+	Name string
+
+	// Value is the formatted metric value.
+	Value string
+}